@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"log/slog"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type backupCollector struct {
+	client           *client.Client
+	config           *config.ArrConfig // App configuration
+	backupMetric     *prometheus.Desc  // Total number of backups
+	latestBackupTime *prometheus.Desc  // Time of the newest backup per type
+	latestBackupSize *prometheus.Desc  // Size of the newest backup per type
+	errorMetric      *prometheus.Desc  // Error Description for use with InvalidMetric
+}
+
+// NewBackupCollector builds a collector for the system/backup endpoint, so
+// backup freshness can be alerted on per backup type (scheduled, manual,
+// update).
+func NewBackupCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
+	return &backupCollector{
+		client:       httpClient,
+		config:       c,
+		backupMetric: newDesc(c.App, "backup_total", "Total number of backups", nil, c.URL),
+		latestBackupTime: newDesc(c.App, "backup_latest_timestamp_seconds",
+			"Time of the newest backup by type", []string{"type"}, c.URL),
+		latestBackupSize: newDesc(c.App, "backup_latest_size_bytes",
+			"Size of the newest backup by type in bytes", []string{"type"}, c.URL),
+		errorMetric: newDesc(c.App, "backup_collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+}
+
+func (collector *backupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.errorMetric
+	ch <- collector.backupMetric
+	ch <- collector.latestBackupTime
+	ch <- collector.latestBackupSize
+}
+
+func (collector *backupCollector) Collect(ch chan<- prometheus.Metric) {
+	log := slog.With("collector", "backup")
+	defer recoverCollect(log, ch, collector.errorMetric)
+	c := collector.client
+
+	backups, err := client.Get[model.Backups](c, "system/backup")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting backups", "error", err)
+		return
+	}
+
+	// Keep the newest backup of each type.
+	latest := map[string]int{}
+	for i, b := range backups {
+		if j, ok := latest[b.Type]; !ok || b.Time.After(backups[j].Time) {
+			latest[b.Type] = i
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collector.backupMetric, prometheus.GaugeValue, float64(len(backups)))
+	for backupType, i := range latest {
+		ch <- prometheus.MustNewConstMetric(collector.latestBackupTime, prometheus.GaugeValue, float64(backups[i].Time.Unix()), backupType)
+		ch <- prometheus.MustNewConstMetric(collector.latestBackupSize, prometheus.GaugeValue, float64(backups[i].Size), backupType)
+	}
+}
@@ -0,0 +1,149 @@
+package collector
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// blocklistPageSize is how many blocklist entries are requested per page.
+	blocklistPageSize = 100
+	// maxBlocklistPages bounds how far back a single scrape walks the
+	// blocklist, like maxLogPages does for the log.
+	maxBlocklistPages = 10
+)
+
+// pendingStatuses are the queue statuses of releases held back instead of
+// sent to a download client: by a delay profile, because no client is
+// available, or while waiting to fall back to another protocol.
+var pendingStatuses = map[string]bool{
+	"delay":                     true,
+	"downloadclientunavailable": true,
+	"fallback":                  true,
+}
+
+// blocklistCount is an accumulated number of blocklisted releases for one
+// protocol and indexer.
+type blocklistCount struct {
+	Protocol string
+	Indexer  string
+	Count    int
+}
+
+// mergeBlocklistCounts folds a windowed blocklist sample into the
+// accumulated entry.
+func mergeBlocklistCounts(prev, next blocklistCount) blocklistCount {
+	prev.Protocol = next.Protocol
+	prev.Indexer = next.Indexer
+	prev.Count += next.Count
+	return prev
+}
+
+type blocklistCollector struct {
+	client               *client.Client
+	config               *config.ArrConfig          // App configuration
+	blocklistCache       *statCache[blocklistCount] // Cache of blocklist additions
+	blocklistWindow      *eventWindow               // How far the blocklist has been read
+	blocklistMetric      *prometheus.Desc           // Total number of blocklisted releases
+	blocklistAddedMetric *prometheus.Desc           // Blocklist additions by protocol and indexer
+	pendingMetric        *prometheus.Desc           // Number of pending releases by reason
+	pendingOldestMetric  *prometheus.Desc           // Age of the oldest pending release
+	errorMetric          *prometheus.Desc           // Error Description for use with InvalidMetric
+}
+
+// NewBlocklistCollector builds a collector for blocklisted and pending
+// releases. Blocklist additions are counted from the entries added since the
+// previous scrape, so like the log counters they start at zero when the
+// exporter starts.
+func NewBlocklistCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
+	return &blocklistCollector{
+		client:          httpClient,
+		config:          c,
+		blocklistCache:  newStatCache(mergeBlocklistCounts),
+		blocklistWindow: newEventWindow(now()),
+		blocklistMetric: newDesc(c.App, "blocklist_total", "Total number of blocklisted releases", nil, c.URL),
+		blocklistAddedMetric: newDesc(c.App, "blocklist_added_total",
+			"Total number of releases blocklisted by protocol and indexer since the exporter started", []string{"protocol", "indexer"}, c.URL),
+		pendingMetric: newDesc(c.App, "pending_total",
+			"Number of releases held back as pending by reason", []string{"reason"}, c.URL),
+		pendingOldestMetric: newDesc(c.App, "pending_oldest_age_seconds",
+			"Time since the oldest pending release was grabbed (0 when nothing is pending)", nil, c.URL),
+		errorMetric: newDesc(c.App, "blocklist_collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+}
+
+func (collector *blocklistCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.errorMetric
+	ch <- collector.blocklistMetric
+	ch <- collector.blocklistAddedMetric
+	ch <- collector.pendingMetric
+	ch <- collector.pendingOldestMetric
+}
+
+func (collector *blocklistCollector) Collect(ch chan<- prometheus.Metric) {
+	log := slog.With("collector", "blocklist")
+	defer recoverCollect(log, ch, collector.errorMetric)
+	c := collector.client
+
+	params := client.QueryParams{}
+	params.Add("pageSize", strconv.Itoa(blocklistPageSize))
+	params.Add("sortKey", "date")
+	params.Add("sortDirection", "descending")
+
+	total, truncated, err := walkNewest(collector.blocklistWindow, blocklistPageSize, maxBlocklistPages,
+		func(page int) ([]model.BlocklistRecord, int, error) {
+			params.Set("page", strconv.Itoa(page))
+			blocklist, err := client.Get[model.Blocklist](c, "blocklist", params)
+			if err != nil {
+				return nil, 0, fmt.Errorf("page %d: %w", page, err)
+			}
+			return blocklist.Records, blocklist.TotalRecords, nil
+		},
+		func(rec model.BlocklistRecord) (int, time.Time) { return rec.ID, rec.Date },
+		func(rec model.BlocklistRecord) {
+			protocol, indexer := orUnknown(rec.Protocol), orUnknown(rec.Indexer)
+			collector.blocklistCache.Update(protocol+"/"+indexer, blocklistCount{Protocol: protocol, Indexer: indexer, Count: 1})
+		})
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting blocklist", "error", err)
+		return
+	}
+	if truncated {
+		log.Warn("Blocklist window exceeds the page budget; older entries in it are not counted", "pages", maxBlocklistPages)
+	}
+
+	queue, err := client.Get[model.QueueDetails](c, "queue/details")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting queue details", "error", err)
+		return
+	}
+	current := now()
+	pending := map[string]int{}
+	var oldest time.Duration
+	for _, q := range queue {
+		if !pendingStatuses[strings.ToLower(q.Status)] {
+			continue
+		}
+		pending[q.Status]++
+		if !q.Added.IsZero() {
+			oldest = max(oldest, current.Sub(q.Added))
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collector.blocklistMetric, prometheus.GaugeValue, float64(total))
+	for _, bc := range collector.blocklistCache.Values() {
+		ch <- prometheus.MustNewConstMetric(collector.blocklistAddedMetric, prometheus.CounterValue, float64(bc.Count), bc.Protocol, bc.Indexer)
+	}
+	for reason, count := range pending {
+		ch <- prometheus.MustNewConstMetric(collector.pendingMetric, prometheus.GaugeValue, float64(count), reason)
+	}
+	ch <- prometheus.MustNewConstMetric(collector.pendingOldestMetric, prometheus.GaugeValue, oldest.Seconds())
+}
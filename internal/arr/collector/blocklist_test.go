@@ -0,0 +1,115 @@
+package collector
+
+import (
+	"github.com/onedr0p/exportarr/internal/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/fixtures"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestBlocklistCollect(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config *config.ArrConfig
+		prefix string
+	}{
+		{
+			name: "radarr",
+			config: &config.ArrConfig{
+				App:        "radarr",
+				APIVersion: "v3",
+			},
+			prefix: "/api/v3/",
+		},
+		{
+			name: "sonarr",
+			config: &config.ArrConfig{
+				App:        "sonarr",
+				APIVersion: "v3",
+			},
+			prefix: "/api/v3/",
+		},
+		{
+			name: "lidarr",
+			config: &config.ArrConfig{
+				App:        "lidarr",
+				APIVersion: "v1",
+			},
+			prefix: "/api/v1/",
+		},
+	}
+
+	// The exporter starts at midnight: the entry from the previous evening
+	// predates the window and must not be counted, and the oldest pending
+	// release was grabbed at 18:00.
+	pinNow(t, time.Date(2023, 10, 13, 0, 0, 0, 0, time.UTC))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := fixtures.NewTestSharedServer(t, func(_ http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, tt.prefix)
+				if strings.HasSuffix(r.URL.Path, "/blocklist") {
+					assert.Equal(t, r.URL.Query().Get("sortKey"), "date")
+					assert.Equal(t, r.URL.Query().Get("sortDirection"), "descending")
+				}
+			})
+			assert.NoError(t, err)
+
+			defer ts.Close()
+
+			tt.config.URL = ts.URL
+			tt.config.APIKey = fixtures.APIKey
+
+			cl, err := client.NewClient(tt.config)
+			assert.NoError(t, err)
+			collector := NewBlocklistCollector(cl, tt.config)
+
+			b, err := os.ReadFile(fixtures.CommonFixturesPath + "expected_blocklist_metrics.txt")
+			assert.NoError(t, err)
+
+			expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+			expected = strings.ReplaceAll(expected, "APP", tt.config.App)
+
+			// The second scrape sees the same entries again; none of them are
+			// newer than the first scrape's window, so the counts must hold.
+			for scrape := 1; scrape <= 2; scrape++ {
+				f := strings.NewReader(expected)
+				assert.NotPanics(t, func() {
+					err = testutil.CollectAndCompare(collector, f)
+				})
+				assert.NoError(t, err, "scrape %d", scrape)
+			}
+		})
+	}
+}
+
+func TestBlocklistCollect_FailureDoesntPanic(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:    ts.URL,
+		APIKey: fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewBlocklistCollector(cl, config)
+
+	f := strings.NewReader("")
+
+	assert.NotPanics(t, func() {
+		err := testutil.CollectAndCompare(collector, f)
+		assert.Error(t, err)
+	}, "Collecting metrics should not panic on failure")
+}
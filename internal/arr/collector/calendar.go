@@ -0,0 +1,156 @@
+package collector
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type calendarCollector struct {
+	client            *client.Client
+	config            *config.ArrConfig // App configuration
+	upcomingMetric    *prometheus.Desc  // Monitored releases due within each window
+	missingMetric     *prometheus.Desc  // Monitored releases in the lookback without a file
+	nextReleaseMetric *prometheus.Desc  // Time of the next monitored release
+	errorMetric       *prometheus.Desc  // Error Description for use with InvalidMetric
+}
+
+// NewCalendarCollector builds a collector for the calendar endpoint: what is
+// about to release, and what already released but never downloaded, without
+// walking the whole wanted list.
+func NewCalendarCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
+	return &calendarCollector{
+		client: httpClient,
+		config: c,
+		upcomingMetric: newDesc(c.App, "calendar_upcoming_total",
+			"Number of monitored releases due within the window", []string{"window"}, c.URL),
+		missingMetric: newDesc(c.App, "calendar_missing_total",
+			"Number of monitored releases in the lookback window that still have no file", nil, c.URL),
+		nextReleaseMetric: newDesc(c.App, "calendar_next_release_timestamp_seconds",
+			"Time of the next monitored release", nil, c.URL),
+		errorMetric: newDesc(c.App, "calendar_collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+}
+
+func (collector *calendarCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.errorMetric
+	ch <- collector.upcomingMetric
+	ch <- collector.missingMetric
+	ch <- collector.nextReleaseMetric
+}
+
+func (collector *calendarCollector) Collect(ch chan<- prometheus.Metric) {
+	log := slog.With("collector", "calendar")
+	defer recoverCollect(log, ch, collector.errorMetric)
+	c := collector.client
+
+	current := now()
+	var horizon time.Duration
+	for _, w := range collector.config.CalendarWindows {
+		horizon = max(horizon, w)
+	}
+	start := current.Add(-collector.config.CalendarLookback)
+
+	params := client.QueryParams{}
+	params.Add("start", start.UTC().Format(time.RFC3339))
+	params.Add("end", current.Add(horizon).UTC().Format(time.RFC3339))
+	params.Add("unmonitored", "false")
+	entries, err := client.Get[model.CalendarEntries](c, "calendar", params)
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting calendar", "error", err)
+		return
+	}
+
+	upcoming := make([]int, len(collector.config.CalendarWindows))
+	missing := 0
+	var next time.Time
+	for _, e := range entries {
+		// unmonitored=false is a hint; sonarr still returns unmonitored
+		// episodes of monitored series.
+		if !e.Monitored {
+			continue
+		}
+		releases := calendarReleases(collector.config.App, e)
+		for i, w := range collector.config.CalendarWindows {
+			if slices.ContainsFunc(releases, func(t time.Time) bool {
+				return t.After(current) && !t.After(current.Add(w))
+			}) {
+				upcoming[i]++
+			}
+		}
+		aired := slices.ContainsFunc(calendarAvailability(collector.config.App, e), func(t time.Time) bool {
+			return !t.Before(start) && !t.After(current)
+		})
+		if aired && !calendarHasFile(collector.config.App, e) {
+			missing++
+		}
+		for _, t := range releases {
+			if t.After(current) && (next.IsZero() || t.Before(next)) {
+				next = t
+			}
+		}
+	}
+
+	for i, w := range collector.config.CalendarWindows {
+		ch <- prometheus.MustNewConstMetric(collector.upcomingMetric, prometheus.GaugeValue, float64(upcoming[i]), formatWindow(w))
+	}
+	ch <- prometheus.MustNewConstMetric(collector.missingMetric, prometheus.GaugeValue, float64(missing))
+	if !next.IsZero() {
+		ch <- prometheus.MustNewConstMetric(collector.nextReleaseMetric, prometheus.GaugeValue, float64(next.Unix()))
+	}
+}
+
+// calendarReleases returns the release times of an entry: an episode's air
+// date, an album's release date, or a movie's cinema, digital and physical
+// releases.
+func calendarReleases(app string, e model.CalendarEntry) []time.Time {
+	var candidates []time.Time
+	switch app {
+	case "sonarr":
+		candidates = []time.Time{e.AirDateUtc}
+	case "radarr":
+		candidates = []time.Time{e.InCinemas, e.DigitalRelease, e.PhysicalRelease}
+	case "lidarr":
+		candidates = []time.Time{e.ReleaseDate}
+	}
+	return slices.DeleteFunc(candidates, time.Time.IsZero)
+}
+
+// calendarAvailability returns the release times after which an entry is
+// expected to have a file. A movie only in cinemas can't be grabbed yet, so
+// only its digital and physical releases count, matching
+// movie_release_passed_missing_total.
+func calendarAvailability(app string, e model.CalendarEntry) []time.Time {
+	if app == "radarr" {
+		return slices.DeleteFunc([]time.Time{e.DigitalRelease, e.PhysicalRelease}, time.Time.IsZero)
+	}
+	return calendarReleases(app, e)
+}
+
+// calendarHasFile reports whether an entry has been downloaded; lidarr
+// albums count as downloaded once all of their tracks are on disk, as in
+// lidarr_albums_type_total.
+func calendarHasFile(app string, e model.CalendarEntry) bool {
+	if app == "lidarr" {
+		return e.Statistics.TrackCount > 0 && e.Statistics.TrackFileCount >= e.Statistics.TrackCount
+	}
+	return e.HasFile
+}
+
+// formatWindow renders a window as a compact label: 24h, 1h30m, 45m.
+func formatWindow(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"github.com/onedr0p/exportarr/internal/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/fixtures"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCalendarCollect(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config *config.ArrConfig
+		dir    string
+	}{
+		{
+			name: "radarr",
+			config: &config.ArrConfig{
+				App:        "radarr",
+				APIVersion: "v3",
+			},
+			dir: "../testdata/radarr/",
+		},
+		{
+			name: "sonarr",
+			config: &config.ArrConfig{
+				App:        "sonarr",
+				APIVersion: "v3",
+			},
+			dir: "../testdata/sonarr/",
+		},
+		{
+			name: "lidarr",
+			config: &config.ArrConfig{
+				App:        "lidarr",
+				APIVersion: "v3",
+			},
+			dir: "../testdata/lidarr/",
+		},
+	}
+
+	pinNow(t, time.Date(2023, 10, 14, 12, 0, 0, 0, time.UTC))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := fixtures.NewTestServer(t, tt.dir, func(_ http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, "/calendar")
+				assert.Equal(t, r.URL.Query().Get("start"), "2023-10-07T12:00:00Z")
+				assert.Equal(t, r.URL.Query().Get("end"), "2023-10-21T12:00:00Z")
+				assert.Equal(t, r.URL.Query().Get("unmonitored"), "false")
+			})
+			assert.NoError(t, err)
+
+			defer ts.Close()
+
+			tt.config.URL = ts.URL
+			tt.config.APIKey = fixtures.APIKey
+			tt.config.CalendarWindows = []time.Duration{24 * time.Hour, 168 * time.Hour}
+			tt.config.CalendarLookback = 168 * time.Hour
+
+			cl, err := client.NewClient(tt.config)
+			assert.NoError(t, err)
+			collector := NewCalendarCollector(cl, tt.config)
+
+			b, err := os.ReadFile(fixtures.CommonFixturesPath + "expected_calendar_metrics.txt")
+			assert.NoError(t, err)
+
+			expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+			expected = strings.ReplaceAll(expected, "APP", tt.config.App)
+
+			f := strings.NewReader(expected)
+			assert.NotPanics(t, func() {
+				err = testutil.CollectAndCompare(collector, f)
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFormatWindow(t *testing.T) {
+	assert.Equal(t, formatWindow(24*time.Hour), "24h")
+	assert.Equal(t, formatWindow(90*time.Minute), "1h30m")
+	assert.Equal(t, formatWindow(45*time.Minute), "45m")
+	assert.Equal(t, formatWindow(30*time.Second), "30s")
+}
+
+func TestCalendarCollect_FailureDoesntPanic(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:    ts.URL,
+		APIKey: fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewCalendarCollector(cl, config)
+
+	f := strings.NewReader("")
+
+	assert.NotPanics(t, func() {
+		err := testutil.CollectAndCompare(collector, f)
+		assert.Error(t, err)
+	}, "Collecting metrics should not panic on failure")
+}
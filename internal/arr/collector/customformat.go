@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// formatScoreBuckets are the inclusive upper bounds of the custom-format
+// score histogram. Scores are relative to the profile's cutoff format score,
+// so every observation at or below -1 is a file that still wants upgrading.
+var formatScoreBuckets = []float64{-1000, -500, -250, -100, -50, -10, -1, 0, 100, 500, 1000}
+
+// customFormatTally accumulates custom formats and scores across files.
+type customFormatTally struct {
+	files  map[string]int         // Files per custom format name
+	deltas map[string]map[int]int // Files per profile and score-minus-cutoff
+}
+
+func newCustomFormatTally() *customFormatTally {
+	return &customFormatTally{
+		files:  map[string]int{},
+		deltas: map[string]map[int]int{},
+	}
+}
+
+// add records one file. profile is nil when the owning item's profile is not
+// known, in which case the score is taken relative to zero.
+func (t *customFormatTally) add(formats []model.CustomFormat, score int, profile *model.QualityProfile) {
+	for _, cf := range formats {
+		t.files[cf.Name]++
+	}
+	name, cutoff := "unknown", 0
+	if profile != nil {
+		name, cutoff = profile.Name, profile.CutoffFormatScore
+	}
+	if t.deltas[name] == nil {
+		t.deltas[name] = map[int]int{}
+	}
+	t.deltas[name][score-cutoff]++
+}
+
+// customFormatMetrics holds the custom format descriptors shared by the
+// radarr and sonarr collectors.
+type customFormatMetrics struct {
+	files *prometheus.Desc // Number of files per custom format
+	score *prometheus.Desc // Histogram of file scores relative to the profile cutoff
+}
+
+func newCustomFormatMetrics(app, url string) customFormatMetrics {
+	return customFormatMetrics{
+		files: newDesc(app, "customformat_files_total",
+			"Number of files matching each custom format", []string{"custom_format"}, url),
+		score: newDesc(app, "customformat_score_cutoff_delta",
+			"Custom format score of files relative to their quality profile's cutoff format score (negative still needs upgrading)",
+			[]string{"quality_profile"}, url),
+	}
+}
+
+func (m customFormatMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- m.files
+	ch <- m.score
+}
+
+func (m customFormatMetrics) collect(ch chan<- prometheus.Metric, t *customFormatTally) {
+	for name, count := range t.files {
+		ch <- prometheus.MustNewConstMetric(m.files, prometheus.GaugeValue, float64(count), name)
+	}
+	for profile, deltas := range t.deltas {
+		var count uint64
+		var sum float64
+		buckets := make(map[float64]uint64, len(formatScoreBuckets))
+		for _, ub := range formatScoreBuckets {
+			buckets[ub] = 0 // emit every bucket, even when empty
+		}
+		for delta, n := range deltas {
+			count += uint64(n) //nolint:gosec // file counts are small and non-negative
+			sum += float64(delta * n)
+			for _, ub := range formatScoreBuckets {
+				if float64(delta) <= ub {
+					buckets[ub] += uint64(n) //nolint:gosec // as above
+				}
+			}
+		}
+		ch <- prometheus.MustNewConstHistogram(m.score, count, sum, buckets, profile)
+	}
+}
+
+// profilesByID indexes quality profiles by ID.
+func profilesByID(profiles model.QualityProfiles) map[int]*model.QualityProfile {
+	out := make(map[int]*model.QualityProfile, len(profiles))
+	for i := range profiles {
+		out[profiles[i].ID] = &profiles[i]
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+package collector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/onedr0p/exportarr/internal/assert"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCustomFormatTally(t *testing.T) {
+	hd := &model.QualityProfile{Name: "HD", CutoffFormatScore: 100}
+	uhd := &model.QualityProfile{Name: "UHD", CutoffFormatScore: 0}
+	type file struct {
+		formats []string
+		score   int
+		profile *model.QualityProfile
+	}
+	tests := []struct {
+		name       string
+		files      []file
+		wantFiles  map[string]int
+		wantDeltas map[string]map[int]int
+	}{
+		{
+			name:       "empty",
+			wantFiles:  map[string]int{},
+			wantDeltas: map[string]map[int]int{},
+		},
+		{
+			name: "score relative to the profile cutoff",
+			files: []file{
+				{formats: []string{"HDR", "DV"}, score: 150, profile: hd},
+				{formats: []string{"HDR"}, score: 100, profile: hd},
+				{score: 40, profile: hd},
+				{formats: []string{"DV"}, score: 40, profile: uhd},
+			},
+			wantFiles: map[string]int{"HDR": 2, "DV": 2},
+			wantDeltas: map[string]map[int]int{
+				"HD":  {50: 1, 0: 1, -60: 1},
+				"UHD": {40: 1},
+			},
+		},
+		{
+			name: "unknown profile scores against zero",
+			files: []file{
+				{formats: []string{"x265"}, score: -20},
+				{score: -20},
+			},
+			wantFiles:  map[string]int{"x265": 1},
+			wantDeltas: map[string]map[int]int{"unknown": {-20: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tally := newCustomFormatTally()
+			for _, f := range tt.files {
+				var formats []model.CustomFormat
+				for _, name := range f.formats {
+					formats = append(formats, model.CustomFormat{Name: name})
+				}
+				tally.add(formats, f.score, f.profile)
+			}
+			assert.DeepEqual(t, tally.files, tt.wantFiles)
+			assert.DeepEqual(t, tally.deltas, tt.wantDeltas)
+		})
+	}
+}
+
+// customFormatCollector adapts a tally to prometheus.Collector for testutil.
+type customFormatCollector struct {
+	metrics customFormatMetrics
+	tally   *customFormatTally
+}
+
+func (c customFormatCollector) Describe(ch chan<- *prometheus.Desc) { c.metrics.describe(ch) }
+func (c customFormatCollector) Collect(ch chan<- prometheus.Metric) { c.metrics.collect(ch, c.tally) }
+
+func TestCustomFormatMetrics_ScoreHistogram(t *testing.T) {
+	tests := []struct {
+		name     string
+		deltas   map[int]int
+		expected string
+	}{
+		{
+			name:   "bucket edges are inclusive",
+			deltas: map[int]int{-1000: 1, -1: 2, 0: 1, 1: 1, 1001: 1},
+			expected: `
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-1000"} 1
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-500"} 1
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-250"} 1
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-100"} 1
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-50"} 1
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-10"} 1
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-1"} 3
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="0"} 4
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="100"} 5
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="500"} 5
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="1000"} 5
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="+Inf"} 6
+test_customformat_score_cutoff_delta_sum{quality_profile="HD",url="u"} 0
+test_customformat_score_cutoff_delta_count{quality_profile="HD",url="u"} 6
+`,
+		},
+		{
+			name:   "empty buckets are still emitted",
+			deltas: map[int]int{250: 3},
+			expected: `
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-1000"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-500"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-250"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-100"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-50"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-10"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="-1"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="0"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="100"} 0
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="500"} 3
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="1000"} 3
+test_customformat_score_cutoff_delta_bucket{quality_profile="HD",url="u",le="+Inf"} 3
+test_customformat_score_cutoff_delta_sum{quality_profile="HD",url="u"} 750
+test_customformat_score_cutoff_delta_count{quality_profile="HD",url="u"} 3
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tally := newCustomFormatTally()
+			tally.deltas["HD"] = tt.deltas
+			c := customFormatCollector{metrics: newCustomFormatMetrics("test", "u"), tally: tally}
+			expected := `# HELP test_customformat_score_cutoff_delta Custom format score of files relative to their quality profile's cutoff format score (negative still needs upgrading)
+# TYPE test_customformat_score_cutoff_delta histogram` + tt.expected
+			assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "test_customformat_score_cutoff_delta"))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// growthWindows are the rolling windows library additions are counted over.
+var growthWindows = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"1d", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+}
+
+// itemAgeBuckets are the classic buckets of the item age histogram, from a
+// day to five years. Scrapers that negotiate native histograms get the
+// exponential buckets instead.
+var itemAgeBuckets = []float64{
+	(24 * time.Hour).Seconds(),
+	(7 * 24 * time.Hour).Seconds(),
+	(30 * 24 * time.Hour).Seconds(),
+	(90 * 24 * time.Hour).Seconds(),
+	(180 * 24 * time.Hour).Seconds(),
+	(365 * 24 * time.Hour).Seconds(),
+	(2 * 365 * 24 * time.Hour).Seconds(),
+	(5 * 365 * 24 * time.Hour).Seconds(),
+}
+
+// growthTally accumulates items by when they were added to the library.
+// Counting additions directly keeps the numbers right when other items are
+// deleted in the same window, which deltas of the library size cannot.
+type growthTally struct {
+	now   time.Time
+	items []int
+	bytes []int64
+	ages  []float64
+}
+
+func newGrowthTally(now time.Time) *growthTally {
+	return &growthTally{
+		now:   now,
+		items: make([]int, len(growthWindows)),
+		bytes: make([]int64, len(growthWindows)),
+	}
+}
+
+// addItem records one item added to the library at added. Its size is
+// recorded per file with addBytes, since files arrive, and are upgraded, long
+// after the item was added. Items without an added date are ignored.
+func (t *growthTally) addItem(added time.Time) {
+	if added.IsZero() {
+		return
+	}
+	age := t.now.Sub(added)
+	for i, w := range growthWindows {
+		if age <= w.duration {
+			t.items[i]++
+		}
+	}
+	t.ages = append(t.ages, max(age, 0).Seconds())
+}
+
+// addBytes records size bytes added to the library at added.
+func (t *growthTally) addBytes(added time.Time, size int64) {
+	if added.IsZero() {
+		return
+	}
+	age := t.now.Sub(added)
+	for i, w := range growthWindows {
+		if age <= w.duration {
+			t.bytes[i] += size
+		}
+	}
+}
+
+// growthMetrics holds the library growth descriptors shared by the library
+// collectors; kind names the item ("series", "movie", "artist").
+type growthMetrics struct {
+	added   *prometheus.Desc         // Items added per window
+	bytes   *prometheus.Desc         // Size of files added per window
+	age     *prometheus.Desc         // Histogram of item age
+	ageOpts prometheus.HistogramOpts // Rebuilt into a fresh histogram each scrape
+}
+
+// newGrowthMetrics builds the growth descriptors for items of kind whose
+// size is counted per fileKind file (see growthTally.addItem).
+func newGrowthMetrics(app, kind, fileKind, url string) growthMetrics {
+	ageOpts := prometheus.HistogramOpts{
+		Name:                           prometheus.BuildFQName(app, "", kind+"_age_seconds"),
+		Help:                           "Time since each " + kind + " was added to the library",
+		ConstLabels:                    prometheus.Labels{"url": url},
+		Buckets:                        itemAgeBuckets,
+		NativeHistogramBucketFactor:    1.1,
+		NativeHistogramMaxBucketNumber: 100,
+	}
+	return growthMetrics{
+		added: newDesc(app, kind+"_added_total",
+			"Number of "+kind+" items added to the library within the window", []string{"window"}, url),
+		bytes: newDesc(app, kind+"_added_bytes",
+			"Size on disk in bytes of "+fileKind+" files added to the library within the window", []string{"window"}, url),
+		age:     newDesc(app, kind+"_age_seconds", ageOpts.Help, nil, url),
+		ageOpts: ageOpts,
+	}
+}
+
+func (m growthMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- m.added
+	ch <- m.bytes
+	ch <- m.age
+}
+
+// collect emits the tally. withBytes is false when the files the bytes are
+// counted from were not fetched, in which case they would read as zero.
+func (m growthMetrics) collect(ch chan<- prometheus.Metric, t *growthTally, withBytes bool) {
+	for i, w := range growthWindows {
+		ch <- prometheus.MustNewConstMetric(m.added, prometheus.GaugeValue, float64(t.items[i]), w.label)
+		if withBytes {
+			ch <- prometheus.MustNewConstMetric(m.bytes, prometheus.GaugeValue, float64(t.bytes[i]), w.label)
+		}
+	}
+	// The library is re-read in full every scrape, so the histogram is a
+	// snapshot rather than a running total.
+	age := prometheus.NewHistogram(m.ageOpts)
+	for _, a := range t.ages {
+		age.Observe(a)
+	}
+	ch <- age
+}
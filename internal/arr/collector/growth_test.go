@@ -0,0 +1,71 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onedr0p/exportarr/internal/assert"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestGrowthTally(t *testing.T) {
+	current := time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC)
+	tally := newGrowthTally(current)
+	for _, f := range []struct {
+		added time.Time
+		size  int64
+	}{
+		{current.Add(-24 * time.Hour), 10},      // exactly on the 1d boundary
+		{current.Add(-3 * 24 * time.Hour), 20},  // 7d and 30d
+		{current.Add(-60 * 24 * time.Hour), 40}, // outside every window
+		{time.Time{}, 80},                       // no added date
+		{current.Add(time.Hour), 160},           // clock skew: counts as brand new
+	} {
+		tally.addItem(f.added)
+		tally.addBytes(f.added, f.size)
+	}
+
+	assert.DeepEqual(t, tally.items, []int{2, 3, 3})
+	assert.DeepEqual(t, tally.bytes, []int64{170, 190, 190})
+	assert.Equal(t, len(tally.ages), 4)
+	assert.Equal(t, tally.ages[3], 0.0)
+}
+
+func TestGrowthTally_FileBytes(t *testing.T) {
+	current := time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC)
+	tally := newGrowthTally(current)
+	// A series added long ago gains an episode this week: the series isn't
+	// new, but the bytes are.
+	tally.addItem(current.Add(-400 * 24 * time.Hour))
+	tally.addBytes(current.Add(-2*24*time.Hour), 50)
+	tally.addBytes(current.Add(-400*24*time.Hour), 500)
+
+	assert.DeepEqual(t, tally.items, []int{0, 0, 0})
+	assert.DeepEqual(t, tally.bytes, []int64{0, 50, 50})
+	assert.Equal(t, len(tally.ages), 1)
+}
+
+func TestGrowthMetrics_NativeHistogram(t *testing.T) {
+	m := newGrowthMetrics("radarr", "movie", "movie", "http://localhost")
+	tally := newGrowthTally(time.Now())
+	tally.addItem(time.Now().Add(-48 * time.Hour))
+
+	ch := make(chan prometheus.Metric, 16)
+	m.collect(ch, tally, true)
+	close(ch)
+
+	var h *dto.Histogram
+	for metric := range ch {
+		if metric.Desc().String() != m.age.String() {
+			continue
+		}
+		var out dto.Metric
+		assert.NoError(t, metric.Write(&out))
+		h = out.GetHistogram()
+	}
+	assert.True(t, h != nil, "age histogram must be collected")
+	assert.Equal(t, h.GetSampleCount(), uint64(1))
+	assert.Equal(t, len(h.GetBucket()), len(itemAgeBuckets))
+	assert.True(t, len(h.GetPositiveSpan()) > 0, "age histogram must carry native buckets")
+}
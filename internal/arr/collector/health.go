@@ -2,6 +2,10 @@ package collector
 
 import (
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/onedr0p/exportarr/internal/arr/client"
 	"github.com/onedr0p/exportarr/internal/arr/config"
@@ -9,12 +13,31 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// healthSeverities ranks the health check types the apps report. Types not
+// listed here are treated as warnings rather than hidden.
+var healthSeverities = map[string]float64{
+	"ok":      0,
+	"notice":  1,
+	"warning": 2,
+	"error":   3,
+}
+
+// healthIssueKey identifies a health issue independently of its message,
+// which embeds variable text such as indexer lists and paths.
+type healthIssueKey struct {
+	source, issueType string
+}
+
 type systemHealthCollector struct {
 	client             *client.Client
-	config             *config.ArrConfig          // App configuration
-	systemHealthMetric *prometheus.Desc           // Total number of health issues
-	errorMetric        *prometheus.Desc           // Error Description for use with InvalidMetric
-	extraEmitters      []ExtraHealthMetricEmitter // Registered Emitters for extra per-app metrics
+	config             *config.ArrConfig            // App configuration
+	issuesMu           sync.Mutex                   // Guards firstSeen across concurrent scrapes
+	firstSeen          map[healthIssueKey]time.Time // When each current issue was first seen
+	systemHealthMetric *prometheus.Desc             // Total number of health issues (opt-in, with messages)
+	severityMetric     *prometheus.Desc             // Severity of each current issue
+	firstSeenMetric    *prometheus.Desc             // Time each current issue was first seen
+	errorMetric        *prometheus.Desc             // Error Description for use with InvalidMetric
+	extraEmitters      []ExtraHealthMetricEmitter   // Registered Emitters for extra per-app metrics
 }
 
 // ExtraHealthMetricEmitter emits app-specific metrics derived from health
@@ -25,19 +48,31 @@ type ExtraHealthMetricEmitter interface {
 }
 
 // NewSystemHealthCollector builds a collector for the system/health endpoint.
+// Issues are keyed by source and type so their series stay stable while the
+// message text changes; the full message is only exported when health
+// messages are enabled.
 func NewSystemHealthCollector(httpClient *client.Client, c *config.ArrConfig, emitters ...ExtraHealthMetricEmitter) prometheus.Collector {
 	return &systemHealthCollector{
 		client:             httpClient,
 		config:             c,
+		firstSeen:          map[healthIssueKey]time.Time{},
 		systemHealthMetric: newDesc(c.App, "system_health_issues", "Total number of health issues by source, type, message and wikiurl", []string{"source", "type", "message", "wikiurl"}, c.URL),
-		errorMetric:        newDesc(c.App, "health_collector_error", "Error while collecting metrics", nil, c.URL),
-		extraEmitters:      emitters,
+		severityMetric: newDesc(c.App, "system_health_issue_severity",
+			"Severity of each current health issue (0 ok, 1 notice, 2 warning, 3 error)", []string{"source", "type"}, c.URL),
+		firstSeenMetric: newDesc(c.App, "system_health_issue_first_seen_timestamp_seconds",
+			"Time each current health issue was first seen by the exporter", []string{"source", "type"}, c.URL),
+		errorMetric:   newDesc(c.App, "health_collector_error", "Error while collecting metrics", nil, c.URL),
+		extraEmitters: emitters,
 	}
 }
 
 func (collector *systemHealthCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.errorMetric
-	ch <- collector.systemHealthMetric
+	ch <- collector.severityMetric
+	ch <- collector.firstSeenMetric
+	if collector.config.EnableHealthMessages {
+		ch <- collector.systemHealthMetric
+	}
 	for _, emitter := range collector.extraEmitters {
 		ch <- emitter.Describe()
 	}
@@ -52,19 +87,189 @@ func (collector *systemHealthCollector) Collect(ch chan<- prometheus.Metric) {
 		emitError(log, ch, collector.errorMetric, "Error getting health", "error", err)
 		return
 	}
-	// Group metrics by source, type, message and wikiurl
-	if len(systemHealth) > 0 {
-		for _, s := range systemHealth {
+
+	current := map[healthIssueKey]bool{}
+	for _, s := range systemHealth {
+		current[healthIssueKey{s.Source, s.Type}] = true
+		// Group metrics by source, type, message and wikiurl
+		if collector.config.EnableHealthMessages {
 			ch <- prometheus.MustNewConstMetric(collector.systemHealthMetric, prometheus.GaugeValue, float64(1),
 				s.Source, s.Type, s.Message, s.WikiURL,
 			)
-			for _, emitter := range collector.extraEmitters {
-				for _, metric := range emitter.Emit(s) {
-					ch <- metric
-				}
+		}
+		for _, emitter := range collector.extraEmitters {
+			for _, metric := range emitter.Emit(s) {
+				ch <- metric
 			}
 		}
-	} else {
+	}
+	if collector.config.EnableHealthMessages && len(systemHealth) == 0 {
 		ch <- prometheus.MustNewConstMetric(collector.systemHealthMetric, prometheus.GaugeValue, float64(0), "", "", "", "")
 	}
+
+	// An issue that clears and comes back later starts a new first-seen time.
+	collector.issuesMu.Lock()
+	defer collector.issuesMu.Unlock()
+	seenAt := now()
+	for key := range collector.firstSeen {
+		if !current[key] {
+			delete(collector.firstSeen, key)
+		}
+	}
+	for key := range current {
+		first, ok := collector.firstSeen[key]
+		if !ok {
+			first = seenAt
+			collector.firstSeen[key] = first
+		}
+		severity, ok := healthSeverities[strings.ToLower(key.issueType)]
+		if !ok {
+			severity = healthSeverities["warning"]
+		}
+		ch <- prometheus.MustNewConstMetric(collector.severityMetric, prometheus.GaugeValue, severity, key.source, key.issueType)
+		ch <- prometheus.MustNewConstMetric(collector.firstSeenMetric, prometheus.GaugeValue, float64(first.Unix()), key.source, key.issueType)
+	}
+}
+
+// allUnavailable is the name reported when a health check says every item of
+// its kind is unavailable, as in "All download clients are unavailable due to
+// failures", which lists no names.
+const allUnavailable = "all"
+
+// healthMessageNames returns the comma-separated names a health message lists
+// after its first colon, such as the connections in "Notifications
+// unavailable due to failures: Discord, Webhook". A message reporting that
+// all of them are unavailable returns allUnavailable.
+func healthMessageNames(message string) []string {
+	_, list, ok := strings.Cut(message, ": ")
+	if !ok {
+		if strings.HasPrefix(message, "All ") && strings.Contains(message, " unavailable") {
+			return []string{allUnavailable}
+		}
+		return nil
+	}
+	var names []string
+	for name := range strings.SplitSeq(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// healthMessagePaths returns the paths a health message lists after its first
+// colon. Paths may contain commas, so the apps separate them with " | ", as in
+// "Multiple root folders are missing: /tv | /anime".
+func healthMessagePaths(message string) []string {
+	_, list, ok := strings.Cut(message, ": ")
+	if !ok {
+		return nil
+	}
+	var paths []string
+	for path := range strings.SplitSeq(list, " | ") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// healthWikiReason returns the anchor of a health check's wiki URL. Checks
+// whose messages name no list of items still link to a stable anchor per
+// cause, which makes a low-cardinality reason label.
+func healthWikiReason(wikiURL string) string {
+	_, anchor, _ := strings.Cut(wikiURL, "#")
+	return orUnknown(anchor)
+}
+
+// HealthListEmitter emits one series per item a health check lists in its
+// message, such as each client in "Download clients are unavailable due to
+// failures: A, B".
+type HealthListEmitter struct {
+	desc    *prometheus.Desc
+	sources []string
+	items   func(message string) []string
+}
+
+// Describe returns the emitter's metric descriptor.
+func (e *HealthListEmitter) Describe() *prometheus.Desc {
+	return e.desc
+}
+
+// Emit derives one metric per listed item from a health message.
+func (e *HealthListEmitter) Emit(msg model.SystemHealthMessage) []prometheus.Metric {
+	if !slices.Contains(e.sources, msg.Source) {
+		return nil
+	}
+	ret := []prometheus.Metric{}
+	seen := map[string]bool{}
+	for _, item := range e.items(msg.Message) {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		ret = append(ret, prometheus.MustNewConstMetric(e.desc, prometheus.GaugeValue, 1, item))
+	}
+	return ret
+}
+
+// HealthReasonEmitter emits a series per cause for health checks whose
+// messages don't list items, taking the cause from the wiki URL anchor.
+type HealthReasonEmitter struct {
+	desc   *prometheus.Desc
+	source string
+}
+
+// Describe returns the emitter's metric descriptor.
+func (e *HealthReasonEmitter) Describe() *prometheus.Desc {
+	return e.desc
+}
+
+// Emit derives a metric for the check's cause from a health message.
+func (e *HealthReasonEmitter) Emit(msg model.SystemHealthMessage) []prometheus.Metric {
+	if msg.Source != e.source {
+		return nil
+	}
+	return []prometheus.Metric{prometheus.MustNewConstMetric(e.desc, prometheus.GaugeValue, 1, healthWikiReason(msg.WikiURL))}
+}
+
+// NewUnavailableIndexerEmitter builds an emitter for <app>_indexer_unavailable
+// from the indexer status health checks.
+func NewUnavailableIndexerEmitter(app, url string) *HealthListEmitter {
+	return &HealthListEmitter{
+		desc:    newDesc(app, "indexer_unavailable", "Indexers marked unavailable due to repeated errors", []string{"indexer"}, url),
+		sources: []string{"IndexerStatusCheck", "IndexerLongTermStatusCheck"},
+		items:   healthMessageNames,
+	}
+}
+
+// SharedHealthEmitters returns the emitters for the structured health checks
+// common to radarr, sonarr and lidarr.
+func SharedHealthEmitters(c *config.ArrConfig) []ExtraHealthMetricEmitter {
+	return []ExtraHealthMetricEmitter{
+		NewUnavailableIndexerEmitter(c.App, c.URL),
+		&HealthListEmitter{
+			desc:    newDesc(c.App, "download_client_unavailable", "Download clients marked unavailable due to repeated errors", []string{"client"}, c.URL),
+			sources: []string{"DownloadClientStatusCheck"},
+			items:   healthMessageNames,
+		},
+		&HealthListEmitter{
+			desc:    newDesc(c.App, "import_list_unavailable", "Import lists marked unavailable due to repeated errors", []string{"list"}, c.URL),
+			sources: []string{"ImportListStatusCheck"},
+			items:   healthMessageNames,
+		},
+		&HealthListEmitter{
+			desc:    newDesc(c.App, "root_folder_missing", "Root folders the app reports as missing", []string{"path"}, c.URL),
+			sources: []string{"RootFolderCheck"},
+			items:   healthMessagePaths,
+		},
+		&HealthReasonEmitter{
+			desc:   newDesc(c.App, "remote_path_mapping_issue", "Remote path mapping problems by reason", []string{"reason"}, c.URL),
+			source: "RemotePathMappingCheck",
+		},
+		&HealthReasonEmitter{
+			desc:   newDesc(c.App, "indexer_rss_issue", "Problems preventing RSS sync by reason", []string{"reason"}, c.URL),
+			source: "IndexerRssCheck",
+		},
+	}
 }
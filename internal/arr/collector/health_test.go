@@ -1,15 +1,18 @@
 package collector
 
 import (
+	"fmt"
 	"github.com/onedr0p/exportarr/internal/assert"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	client "github.com/onedr0p/exportarr/internal/arr/client"
 	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
 	"github.com/onedr0p/exportarr/internal/fixtures"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
@@ -46,6 +49,7 @@ func TestSystemHealthCollect(t *testing.T) {
 		},
 	}
 
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts, err := fixtures.NewTestSharedServer(t, func(_ http.ResponseWriter, r *http.Request) {
@@ -60,7 +64,7 @@ func TestSystemHealthCollect(t *testing.T) {
 
 			cl, err := client.NewClient(tt.config)
 			assert.NoError(t, err)
-			collector := NewSystemHealthCollector(cl, tt.config)
+			collector := NewSystemHealthCollector(cl, tt.config, SharedHealthEmitters(tt.config)...)
 
 			b, err := os.ReadFile(fixtures.CommonFixturesPath + "expected_health_metrics.txt")
 			assert.NoError(t, err)
@@ -78,6 +82,126 @@ func TestSystemHealthCollect(t *testing.T) {
 	}
 }
 
+func TestSystemHealthCollect_EnableHealthMessages(t *testing.T) {
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+	ts, err := fixtures.NewTestSharedServer(t, func(_ http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/api/v3/health")
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		App:                  "radarr",
+		APIVersion:           "v3",
+		URL:                  ts.URL,
+		APIKey:               fixtures.APIKey,
+		EnableHealthMessages: true,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewSystemHealthCollector(cl, config)
+
+	b, err := os.ReadFile(fixtures.CommonFixturesPath + "expected_health_metrics_messages.txt")
+	assert.NoError(t, err)
+	expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+	expected = strings.ReplaceAll(expected, "APP", config.App)
+
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
+
+func TestSystemHealthCollect_FirstSeen(t *testing.T) {
+	issue := `[{"source":"IndexerStatusCheck","type":"warning","message":"Indexers unavailable due to failures: %s","wikiUrl":""}]`
+	body := strings.Replace(issue, "%s", "A", 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		App:        "radarr",
+		APIVersion: "v3",
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewSystemHealthCollector(cl, config)
+
+	const name = "radarr_system_health_issue_first_seen_timestamp_seconds"
+	expectFirstSeen := func(at time.Time) {
+		t.Helper()
+		expected := fmt.Sprintf(`# HELP %s Time each current health issue was first seen by the exporter
+# TYPE %s gauge
+%s{source="IndexerStatusCheck",type="warning",url="%s"} %d
+`, name, name, name, ts.URL, at.Unix())
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), name))
+	}
+
+	start := time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC)
+	pinNow(t, start)
+	expectFirstSeen(start)
+
+	// The message text changing does not make it a new issue.
+	body = strings.Replace(issue, "%s", "A, B", 1)
+	pinNow(t, start.Add(time.Hour))
+	expectFirstSeen(start)
+
+	// Once it clears, a recurrence is tracked from when it comes back.
+	body = "[]"
+	pinNow(t, start.Add(2*time.Hour))
+	assert.Equal(t, 0, testutil.CollectAndCount(collector, name))
+	body = strings.Replace(issue, "%s", "A", 1)
+	pinNow(t, start.Add(3*time.Hour))
+	expectFirstSeen(start.Add(3 * time.Hour))
+}
+
+func TestSharedHealthEmitters_EdgeCases(t *testing.T) {
+	emitters := SharedHealthEmitters(&config.ArrConfig{App: "sonarr", URL: "http://localhost:8989"})
+	emit := func(msg model.SystemHealthMessage) int {
+		n := 0
+		for _, e := range emitters {
+			n += len(e.Emit(msg))
+		}
+		return n
+	}
+
+	// "All ... unavailable" messages name nothing, so they get a sentinel.
+	assert.Equal(t, 1, emit(model.SystemHealthMessage{
+		Source:  "DownloadClientStatusCheck",
+		Message: "All download clients are unavailable due to failures",
+	}))
+	assert.Equal(t, 1, emit(model.SystemHealthMessage{
+		Source:  "IndexerStatusCheck",
+		Message: "Indexers unavailable due to failures: Same, Same",
+	}))
+	assert.Equal(t, 1, emit(model.SystemHealthMessage{
+		Source:  "RootFolderCheck",
+		Message: "Missing root folder: C:\\Media, TV",
+	}))
+	assert.Equal(t, "unknown", healthWikiReason(""))
+	assert.Equal(t, 0, emit(model.SystemHealthMessage{Source: "UpdateCheck", Message: "New update is available: 4.0.1"}))
+}
+
+func TestHealthMessageNames(t *testing.T) {
+	var tests = []struct {
+		message string
+		want    []string
+	}{
+		{"Download clients are unavailable due to failures: qBittorrent, SABnzbd", []string{"qBittorrent", "SABnzbd"}},
+		{"All download clients are unavailable due to failures", []string{"all"}},
+		{"All indexers are unavailable due to failures", []string{"all"}},
+		{"All import lists are unavailable due to failures", []string{"all"}},
+		{"Lists unavailable due to failures: ", nil},
+		{"No download client is available", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.DeepEqual(t, healthMessageNames(tt.message), tt.want)
+		})
+	}
+}
+
 func TestSystemHealthCollect_FailureDoesntPanic(t *testing.T) {
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
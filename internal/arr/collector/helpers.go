@@ -3,7 +3,10 @@ package collector
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/model"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 )
@@ -42,6 +45,10 @@ func recoverCollect(log *slog.Logger, ch chan<- prometheus.Metric, errorMetric *
 	}
 }
 
+// now is the clock behind time-relative metrics (ages, overdue and window
+// checks); tests pin it so expected output stays deterministic.
+var now = time.Now
+
 // maxConcurrentSeriesFetches bounds the per-item API fan-out used by the
 // sonarr and lidarr collectors on large libraries.
 const maxConcurrentSeriesFetches = 10
@@ -56,3 +63,15 @@ func newDesc(app, name, help string, variableLabels []string, url string) *prome
 		prometheus.Labels{"url": url},
 	)
 }
+
+// getQualityProfiles fetches the quality profiles library metrics are
+// labelled and grouped by. A failure is logged rather than failing the whole
+// collection: without profiles, items fall back to the "unknown" profile.
+func getQualityProfiles(log *slog.Logger, c *client.Client) model.QualityProfiles {
+	profiles, err := client.Get[model.QualityProfiles](c, "qualityprofile")
+	if err != nil {
+		log.Warn("Error getting quality profiles; labelling items with an unknown profile", "error", err)
+		return nil
+	}
+	return profiles
+}
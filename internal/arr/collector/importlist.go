@@ -0,0 +1,126 @@
+package collector
+
+import (
+	"log/slog"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type importListCollector struct {
+	client                *client.Client
+	config                *config.ArrConfig // App configuration
+	enabledMetric         *prometheus.Desc  // Whether each list is enabled
+	autoAddMetric         *prometheus.Desc  // Whether each list adds items automatically
+	lastSyncMetric        *prometheus.Desc  // Time of each list's last successful sync
+	lastFailureMetric     *prometheus.Desc  // Time of each list's most recent failure
+	escalationLevelMetric *prometheus.Desc  // Failure escalation level per list
+	backoffMetric         *prometheus.Desc  // Whether each list is disabled after failures
+	exclusionsMetric      *prometheus.Desc  // Total number of import list exclusions
+	errorMetric           *prometheus.Desc  // Error Description for use with InvalidMetric
+}
+
+// NewImportListCollector builds a collector for import lists (Trakt, Plex
+// watchlists, IMDb lists, ...), whose sync failures otherwise only show up as
+// items that quietly never get added.
+func NewImportListCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
+	return &importListCollector{
+		client: httpClient,
+		config: c,
+		enabledMetric: newDesc(c.App, "importlist_enabled",
+			"Whether the import list is enabled (1) or not (0)", []string{"list", "implementation"}, c.URL),
+		autoAddMetric: newDesc(c.App, "importlist_auto_add",
+			"Whether the import list adds its items automatically (1) or not (0)", []string{"list"}, c.URL),
+		lastSyncMetric: newDesc(c.App, "importlist_last_sync_timestamp_seconds",
+			"Time of the import list's last successful sync", []string{"list"}, c.URL),
+		lastFailureMetric: newDesc(c.App, "importlist_last_failure_timestamp_seconds",
+			"Time of the import list's most recent failed sync", []string{"list"}, c.URL),
+		escalationLevelMetric: newDesc(c.App, "importlist_escalation_level",
+			"Failure escalation level of the import list; grows with consecutive failures and resets on success", []string{"list"}, c.URL),
+		backoffMetric: newDesc(c.App, "importlist_backoff",
+			"Whether the import list is temporarily disabled after repeated failures (1) or not (0)", []string{"list"}, c.URL),
+		exclusionsMetric: newDesc(c.App, "importlist_exclusions_total",
+			"Total number of import list exclusions", nil, c.URL),
+		errorMetric: newDesc(c.App, "importlist_collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+}
+
+func (collector *importListCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.errorMetric
+	ch <- collector.enabledMetric
+	ch <- collector.autoAddMetric
+	ch <- collector.lastSyncMetric
+	ch <- collector.lastFailureMetric
+	ch <- collector.escalationLevelMetric
+	ch <- collector.backoffMetric
+	ch <- collector.exclusionsMetric
+}
+
+func (collector *importListCollector) Collect(ch chan<- prometheus.Metric) {
+	log := slog.With("collector", "importlist")
+	defer recoverCollect(log, ch, collector.errorMetric)
+	c := collector.client
+
+	lists, err := client.Get[model.ImportLists](c, "importlist")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting import lists", "error", err)
+		return
+	}
+	// Status and exclusions only feed their own metrics: when either fails,
+	// the lists are still reported and the error gauge is raised once.
+	statuses, statusErr := client.Get[model.ImportListStatuses](c, "importlist/status")
+	if statusErr != nil {
+		log.Error("Error getting import list status", "error", statusErr)
+	}
+	byProvider := make(map[int]int, len(statuses))
+	for i, s := range statuses {
+		byProvider[s.ProviderID] = i
+	}
+	exclusionsEndpoint := "importlistexclusion"
+	if collector.config.App == "radarr" {
+		exclusionsEndpoint = "exclusions"
+	}
+	exclusions, exclusionsErr := client.Get[model.ImportListExclusions](c, exclusionsEndpoint)
+	if exclusionsErr != nil {
+		log.Error("Error getting import list exclusions", "error", exclusionsErr)
+	}
+
+	current := now()
+	for _, l := range lists {
+		// Only radarr has an on switch separate from automatic add; sonarr
+		// and lidarr only sync lists that add automatically.
+		enabled, autoAdd := l.EnableAutomaticAdd, l.EnableAutomaticAdd
+		if collector.config.App == "radarr" {
+			enabled, autoAdd = l.Enabled, l.EnableAuto
+		}
+		ch <- prometheus.MustNewConstMetric(collector.enabledMetric, prometheus.GaugeValue, boolToFloat(enabled), l.Name, l.Implementation)
+		ch <- prometheus.MustNewConstMetric(collector.autoAddMetric, prometheus.GaugeValue, boolToFloat(autoAdd), l.Name)
+		if statusErr != nil {
+			continue
+		}
+
+		var escalation int
+		var backoff bool
+		if i, ok := byProvider[l.ID]; ok {
+			s := statuses[i]
+			escalation = s.EscalationLevel
+			backoff = s.DisabledTill.After(current)
+			if !s.LastInfoSync.IsZero() {
+				ch <- prometheus.MustNewConstMetric(collector.lastSyncMetric, prometheus.GaugeValue, float64(s.LastInfoSync.Unix()), l.Name)
+			}
+			if !s.MostRecentFailure.IsZero() {
+				ch <- prometheus.MustNewConstMetric(collector.lastFailureMetric, prometheus.GaugeValue, float64(s.MostRecentFailure.Unix()), l.Name)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(collector.escalationLevelMetric, prometheus.GaugeValue, float64(escalation), l.Name)
+		ch <- prometheus.MustNewConstMetric(collector.backoffMetric, prometheus.GaugeValue, boolToFloat(backoff), l.Name)
+	}
+	if exclusionsErr == nil {
+		ch <- prometheus.MustNewConstMetric(collector.exclusionsMetric, prometheus.GaugeValue, float64(len(exclusions)))
+	}
+	if statusErr != nil || exclusionsErr != nil {
+		ch <- prometheus.MustNewConstMetric(collector.errorMetric, prometheus.GaugeValue, 1)
+	}
+}
@@ -0,0 +1,146 @@
+package collector
+
+import (
+	"fmt"
+	"github.com/onedr0p/exportarr/internal/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/fixtures"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestImportListCollect(t *testing.T) {
+	var tests = []struct {
+		name         string
+		config       *config.ArrConfig
+		fixturesPath string
+	}{
+		{
+			name: "radarr",
+			config: &config.ArrConfig{
+				App:        "radarr",
+				APIVersion: "v3",
+			},
+			fixturesPath: radarrTestFixturesPath,
+		},
+		{
+			name: "sonarr",
+			config: &config.ArrConfig{
+				App:        "sonarr",
+				APIVersion: "v3",
+			},
+			fixturesPath: sonarrTestFixturesPath,
+		},
+		{
+			name: "lidarr",
+			config: &config.ArrConfig{
+				App:        "lidarr",
+				APIVersion: "v3",
+			},
+			fixturesPath: lidarrTestFixturesPath,
+		},
+	}
+
+	// The third list failed at 23:00 and is backed off until 01:00.
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Lists are per app, as are radarr's exclusions; status and the
+			// other apps' exclusions come from the common fixtures.
+			ts := fixtureServer(t, tt.fixturesPath)
+			defer ts.Close()
+
+			tt.config.URL = ts.URL
+			tt.config.APIKey = fixtures.APIKey
+
+			cl, err := client.NewClient(tt.config)
+			assert.NoError(t, err)
+			collector := NewImportListCollector(cl, tt.config)
+
+			b, err := os.ReadFile(tt.fixturesPath + "expected_importlist_metrics.txt")
+			assert.NoError(t, err)
+
+			expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+			f := strings.NewReader(expected)
+
+			assert.NotPanics(t, func() {
+				err = testutil.CollectAndCompare(collector, f)
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestImportListCollect_StatusFailure(t *testing.T) {
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+	fixtureTS := fixtureServer(t, sonarrTestFixturesPath)
+	defer fixtureTS.Close()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/importlist/status") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fixtureTS.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+		App:        "sonarr",
+		APIVersion: "v3",
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewImportListCollector(cl, config)
+
+	// The lists and exclusions are still reported; only the status-derived
+	// series are dropped.
+	expected := strings.NewReader(fmt.Sprintf(`
+		# HELP sonarr_importlist_collector_error Error while collecting metrics
+		# TYPE sonarr_importlist_collector_error gauge
+		sonarr_importlist_collector_error{url="%[1]s"} 1
+		# HELP sonarr_importlist_enabled Whether the import list is enabled (1) or not (0)
+		# TYPE sonarr_importlist_enabled gauge
+		sonarr_importlist_enabled{implementation="TraktPopularImport",list="Trakt Popular",url="%[1]s"} 1
+		sonarr_importlist_enabled{implementation="PlexImport",list="Plex Watchlist",url="%[1]s"} 0
+		sonarr_importlist_enabled{implementation="IMDbListImport",list="IMDb List",url="%[1]s"} 1
+		# HELP sonarr_importlist_exclusions_total Total number of import list exclusions
+		# TYPE sonarr_importlist_exclusions_total gauge
+		sonarr_importlist_exclusions_total{url="%[1]s"} 3
+	`, ts.URL))
+	assert.NoError(t, testutil.CollectAndCompare(collector, expected,
+		"sonarr_importlist_collector_error", "sonarr_importlist_enabled", "sonarr_importlist_exclusions_total",
+		"sonarr_importlist_escalation_level", "sonarr_importlist_backoff"))
+}
+
+func TestImportListCollect_FailureDoesntPanic(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:    ts.URL,
+		APIKey: fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewImportListCollector(cl, config)
+
+	f := strings.NewReader("")
+
+	assert.NotPanics(t, func() {
+		err := testutil.CollectAndCompare(collector, f)
+		assert.Error(t, err)
+	}, "Collecting metrics should not panic on failure")
+}
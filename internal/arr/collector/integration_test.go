@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	client "github.com/onedr0p/exportarr/internal/arr/client"
 	"github.com/onedr0p/exportarr/internal/arr/config"
@@ -36,6 +37,14 @@ func fixtureServer(t *testing.T, appDir string) *httptest.Server {
 	}))
 }
 
+// pinNow fixes the collectors' clock for the duration of the test.
+func pinNow(t *testing.T, at time.Time) {
+	t.Helper()
+	prev := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = prev })
+}
+
 // TestPerAppCollectorSets registers the exact collector set each command wires
 // up and gathers it against fixture-backed responses: one scrape must succeed
 // end-to-end with no collector errors and no descriptor collisions.
@@ -73,7 +82,15 @@ func TestPerAppCollectorSets(t *testing.T) {
 				NewRootFolderCollector(cl, conf),
 				NewDiskSpaceCollector(cl, conf),
 				NewSystemStatusCollector(cl, conf),
-				NewSystemHealthCollector(cl, conf),
+				NewSystemHealthCollector(cl, conf, SharedHealthEmitters(conf)...),
+				NewSystemTaskCollector(cl, conf),
+				NewBackupCollector(cl, conf),
+				NewLogCollector(cl, conf),
+				NewQualityProfileCollector(cl, conf),
+				NewCalendarCollector(cl, conf),
+				NewBlocklistCollector(cl, conf),
+				NewImportListCollector(cl, conf),
+				NewNotificationCollector(cl, conf),
 			)
 
 			families, err := registry.Gather()
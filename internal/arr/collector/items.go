@@ -0,0 +1,109 @@
+package collector
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// itemSample is one library item (series, movie or artist) considered for
+// the opt-in per-item metrics.
+type itemSample struct {
+	ID              int
+	Title           string
+	Tags            []int
+	Missing         int
+	SizeOnDisk      int64
+	PercentComplete float64
+}
+
+// itemMetrics holds the per-item descriptors shared by the library
+// collectors; kind names the item ("series", "movie", "artist").
+type itemMetrics struct {
+	missing         *prometheus.Desc // Missing episodes/tracks/files per item
+	fileSize        *prometheus.Desc // Size on disk per item
+	percentComplete *prometheus.Desc // Share of wanted content on disk per item
+}
+
+func newItemMetrics(app, kind, url string) itemMetrics {
+	labels := []string{"id", "title"}
+	return itemMetrics{
+		missing: newDesc(app, kind+"_item_missing_total",
+			"Number of missing monitored files per "+kind+" (opt-in, capped by item-metrics-limit)", labels, url),
+		fileSize: newDesc(app, kind+"_item_filesize_bytes",
+			"Size on disk in bytes per "+kind+" (opt-in, capped by item-metrics-limit)", labels, url),
+		percentComplete: newDesc(app, kind+"_item_percent_complete",
+			"Percentage of monitored files on disk per "+kind+" (opt-in, capped by item-metrics-limit)", labels, url),
+	}
+}
+
+func (m itemMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- m.missing
+	ch <- m.fileSize
+	ch <- m.percentComplete
+}
+
+func (m itemMetrics) collect(ch chan<- prometheus.Metric, items []itemSample) {
+	for _, item := range items {
+		id := strconv.Itoa(item.ID)
+		ch <- prometheus.MustNewConstMetric(m.missing, prometheus.GaugeValue, float64(item.Missing), id, item.Title)
+		ch <- prometheus.MustNewConstMetric(m.fileSize, prometheus.GaugeValue, float64(item.SizeOnDisk), id, item.Title)
+		ch <- prometheus.MustNewConstMetric(m.percentComplete, prometheus.GaugeValue, item.PercentComplete, id, item.Title)
+	}
+}
+
+// resolveItemTags maps the configured tag allowlist onto tag IDs. It returns
+// nil when no allowlist is configured, so every item is eligible.
+func resolveItemTags(c *client.Client, conf *config.ArrConfig) (map[int]bool, error) {
+	if len(conf.ItemMetricsTags) == 0 {
+		return nil, nil
+	}
+	tags, err := client.Get[model.Tags](c, "tag")
+	if err != nil {
+		return nil, err
+	}
+	// Always non-nil: an allowlist that matches no tag selects no items.
+	out := map[int]bool{}
+	for _, tag := range tags {
+		for _, want := range conf.ItemMetricsTags {
+			if strings.EqualFold(strings.TrimSpace(want), tag.Label) {
+				out[tag.ID] = true
+			}
+		}
+	}
+	return out, nil
+}
+
+// selectItems applies the tag allowlist (nil allows everything) and keeps the
+// top item-metrics-limit items by the configured sort key, which bounds the
+// cardinality on large libraries.
+func selectItems(items []itemSample, conf *config.ArrConfig, allowed map[int]bool) []itemSample {
+	out := make([]itemSample, 0, len(items))
+	for _, item := range items {
+		if allowed == nil || slices.ContainsFunc(item.Tags, func(id int) bool { return allowed[id] }) {
+			out = append(out, item)
+		}
+	}
+	slices.SortFunc(out, func(a, b itemSample) int {
+		var r int
+		if conf.ItemMetricsSortBy == "missing" {
+			r = cmp.Compare(b.Missing, a.Missing)
+		} else {
+			r = cmp.Compare(b.SizeOnDisk, a.SizeOnDisk)
+		}
+		if r == 0 {
+			r = cmp.Compare(a.ID, b.ID)
+		}
+		return r
+	})
+	if len(out) > conf.ItemMetricsLimit {
+		out = out[:conf.ItemMetricsLimit]
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/assert"
+)
+
+func TestSelectItems(t *testing.T) {
+	items := []itemSample{
+		{ID: 1, Tags: []int{1}, Missing: 5, SizeOnDisk: 100},
+		{ID: 2, Tags: []int{2}, Missing: 50, SizeOnDisk: 10},
+		{ID: 3, Tags: []int{1, 2}, Missing: 0, SizeOnDisk: 1000},
+		{ID: 4, Missing: 50, SizeOnDisk: 1},
+	}
+	ids := func(items []itemSample) []int {
+		out := []int{}
+		for _, item := range items {
+			out = append(out, item.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		sortBy  string
+		limit   int
+		allowed map[int]bool
+		want    []int
+	}{
+		{name: "size", sortBy: "size", limit: 10, want: []int{3, 1, 2, 4}},
+		{name: "missing-ties-by-id", sortBy: "missing", limit: 10, want: []int{2, 4, 1, 3}},
+		{name: "limit", sortBy: "size", limit: 2, want: []int{3, 1}},
+		{name: "tags", sortBy: "missing", limit: 10, allowed: map[int]bool{2: true}, want: []int{2, 3}},
+		{name: "unmatched-tags", sortBy: "size", limit: 10, allowed: map[int]bool{}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config.ArrConfig{ItemMetricsLimit: tt.limit, ItemMetricsSortBy: tt.sortBy}
+			assert.DeepEqual(t, ids(selectItems(items, conf, tt.allowed)), tt.want)
+		})
+	}
+}
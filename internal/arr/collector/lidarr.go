@@ -14,42 +14,55 @@ import (
 )
 
 type lidarrCollector struct {
-	collectMu              sync.Mutex // Guards against overlapping collections (#380)
-	client                 *client.Client
-	config                 *config.ArrConfig // App configuration
-	artistsMetric          *prometheus.Desc  // Total number of artists
-	artistsMonitoredMetric *prometheus.Desc  // Total number of monitored artists
-	artistGenresMetric     *prometheus.Desc  // Total number of artists by genre
-	artistsFileSizeMetric  *prometheus.Desc  // Total fizesize of all artists in bytes
-	albumsMetric           *prometheus.Desc  // Total number of albums
-	albumsMonitoredMetric  *prometheus.Desc  // Total number of monitored albums
-	albumsGenresMetric     *prometheus.Desc  // Total number of albums by genre
-	albumsMissingMetric    *prometheus.Desc  // Total number of missing albums
-	songsMetric            *prometheus.Desc  // Total number of songs
-	songsMonitoredMetric   *prometheus.Desc  // Total number of monitored songs
-	songsDownloadedMetric  *prometheus.Desc  // Total number of downloaded songs
-	songsQualitiesMetric   *prometheus.Desc  // Total number of songs by quality
-	errorMetric            *prometheus.Desc  // Error Description for use with InvalidMetric
+	collectMu               sync.Mutex // Guards against overlapping collections (#380)
+	client                  *client.Client
+	config                  *config.ArrConfig     // App configuration
+	artistsMetric           *prometheus.Desc      // Total number of artists
+	artistsMonitoredMetric  *prometheus.Desc      // Total number of monitored artists
+	artistGenresMetric      *prometheus.Desc      // Total number of artists by genre
+	artistsFileSizeMetric   *prometheus.Desc      // Total fizesize of all artists in bytes
+	albumsMetric            *prometheus.Desc      // Total number of albums
+	albumsMonitoredMetric   *prometheus.Desc      // Total number of monitored albums
+	albumsGenresMetric      *prometheus.Desc      // Total number of albums by genre
+	albumsMissingMetric     *prometheus.Desc      // Total number of missing albums
+	albumsCutoffUnmetMetric *prometheus.Desc      // Total number of albums with cutoff unmet
+	albumsTypeMetric        *prometheus.Desc      // Total number of albums by type and download state
+	artistsTagsMetric       *prometheus.Desc      // Total number of artists by tag
+	songsMetric             *prometheus.Desc      // Total number of songs
+	songsMonitoredMetric    *prometheus.Desc      // Total number of monitored songs
+	songsDownloadedMetric   *prometheus.Desc      // Total number of downloaded songs
+	songsQualitiesMetric    *prometheus.Desc      // Total number of songs by quality
+	itemMetrics             itemMetrics           // Opt-in per-artist metrics
+	qualityProfileMetrics   qualityProfileMetrics // Artists and below-cutoff track files per quality profile
+	growthMetrics           growthMetrics         // Artists and track file bytes added per window, and artist age
+	errorMetric             *prometheus.Desc      // Error Description for use with InvalidMetric
 }
 
 // NewLidarrCollector builds a collector for lidarr library statistics.
 func NewLidarrCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
 	return &lidarrCollector{
-		client:                 httpClient,
-		config:                 c,
-		artistsMetric:          newDesc("lidarr", "artists_total", "Total number of artists", nil, c.URL),
-		artistsMonitoredMetric: newDesc("lidarr", "artists_monitored_total", "Total number of monitored artists", nil, c.URL),
-		artistGenresMetric:     newDesc("lidarr", "artists_genres_total", "Total number of artists by genre", []string{"genre"}, c.URL),
-		artistsFileSizeMetric:  newDesc("lidarr", "artists_filesize_bytes", "Total fizesize of all artists in bytes", nil, c.URL),
-		albumsMetric:           newDesc("lidarr", "albums_total", "Total number of albums", nil, c.URL),
-		albumsMonitoredMetric:  newDesc("lidarr", "albums_monitored_total", "Total number of albums", nil, c.URL),
-		albumsGenresMetric:     newDesc("lidarr", "albums_genres_total", "Total number of albums by genre", []string{"genre"}, c.URL),
-		albumsMissingMetric:    newDesc("lidarr", "albums_missing_total", "Total number of missing albums", nil, c.URL),
-		songsMetric:            newDesc("lidarr", "songs_total", "Total number of songs", nil, c.URL),
-		songsMonitoredMetric:   newDesc("lidarr", "songs_monitored_total", "Total number of monitored songs", nil, c.URL),
-		songsDownloadedMetric:  newDesc("lidarr", "songs_downloaded_total", "Total number of downloaded songs", nil, c.URL),
-		songsQualitiesMetric:   newDesc("lidarr", "songs_quality_total", "Total number of downloaded songs by quality", []string{"quality", "weight"}, c.URL),
-		errorMetric:            newDesc("lidarr", "collector_error", "Error while collecting metrics", nil, c.URL),
+		client:                  httpClient,
+		config:                  c,
+		artistsMetric:           newDesc("lidarr", "artists_total", "Total number of artists", nil, c.URL),
+		artistsMonitoredMetric:  newDesc("lidarr", "artists_monitored_total", "Total number of monitored artists", nil, c.URL),
+		artistGenresMetric:      newDesc("lidarr", "artists_genres_total", "Total number of artists by genre", []string{"genre"}, c.URL),
+		artistsFileSizeMetric:   newDesc("lidarr", "artists_filesize_bytes", "Total fizesize of all artists in bytes", nil, c.URL),
+		albumsMetric:            newDesc("lidarr", "albums_total", "Total number of albums", nil, c.URL),
+		albumsMonitoredMetric:   newDesc("lidarr", "albums_monitored_total", "Total number of albums", nil, c.URL),
+		albumsGenresMetric:      newDesc("lidarr", "albums_genres_total", "Total number of albums by genre", []string{"genre"}, c.URL),
+		albumsMissingMetric:     newDesc("lidarr", "albums_missing_total", "Total number of missing albums", nil, c.URL),
+		albumsCutoffUnmetMetric: newDesc("lidarr", "albums_cutoff_unmet_total", "Total number of albums with cutoff unmet", nil, c.URL),
+		albumsTypeMetric: newDesc("lidarr", "albums_type_total",
+			"Total number of albums by album type and whether all their tracks are downloaded", []string{"album_type", "downloaded"}, c.URL),
+		artistsTagsMetric:     newDesc("lidarr", "artists_tags_total", "Total number of artists by tag", []string{"tag"}, c.URL),
+		songsMetric:           newDesc("lidarr", "songs_total", "Total number of songs", nil, c.URL),
+		songsMonitoredMetric:  newDesc("lidarr", "songs_monitored_total", "Total number of monitored songs", nil, c.URL),
+		songsDownloadedMetric: newDesc("lidarr", "songs_downloaded_total", "Total number of downloaded songs", nil, c.URL),
+		songsQualitiesMetric:  newDesc("lidarr", "songs_quality_total", "Total number of downloaded songs by quality", []string{"quality", "weight"}, c.URL),
+		itemMetrics:           newItemMetrics("lidarr", "artist", c.URL),
+		qualityProfileMetrics: newQualityProfileMetrics("lidarr", c.URL),
+		growthMetrics:         newGrowthMetrics("lidarr", "artist", "track", c.URL),
+		errorMetric:           newDesc("lidarr", "collector_error", "Error while collecting metrics", nil, c.URL),
 	}
 }
 
@@ -63,10 +76,16 @@ func (collector *lidarrCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.albumsMonitoredMetric
 	ch <- collector.albumsGenresMetric
 	ch <- collector.albumsMissingMetric
+	ch <- collector.albumsCutoffUnmetMetric
+	ch <- collector.albumsTypeMetric
+	ch <- collector.artistsTagsMetric
 	ch <- collector.songsMetric
 	ch <- collector.songsMonitoredMetric
 	ch <- collector.songsDownloadedMetric
 	ch <- collector.songsQualitiesMetric
+	collector.itemMetrics.describe(ch)
+	collector.qualityProfileMetrics.describe(ch)
+	collector.growthMetrics.describe(ch)
 }
 
 func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
@@ -90,10 +109,12 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 		albums           = 0
 		albumsMonitored  = 0
 		albumGenres      = map[string]int{}
+		albumTypes       = map[[2]string]int{}
 		songs            = 0
 		songsDownloaded  = 0
 		songsQualities   = map[string]int{}
 		qualityWeights   = map[string]string{}
+		growth           = newGrowthTally(now())
 	)
 
 	artists, err := client.Get[model.Artist](c, "artist")
@@ -102,6 +123,7 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 
+	profileCounts := newProfileTally(getQualityProfiles(log, c))
 	collectQuality := !collector.config.DisableQualityMetrics
 	collectAlbums := !collector.config.DisableAlbumMetrics
 
@@ -127,6 +149,8 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 		songs += s.Statistics.TotalTrackCount
 		songsDownloaded += s.Statistics.TrackFileCount
 		artistsFileSize += s.Statistics.SizeOnDisk
+		growth.addItem(s.Added)
+		profileCounts.addItem(s.QualityProfileID)
 
 		for _, genre := range s.Genres {
 			artistGenres[genre]++
@@ -154,6 +178,9 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 						if e.Quality.Quality.Name != "" {
 							songsQualities[e.Quality.Quality.Name]++
 						}
+						profileCounts.addFile(s.QualityProfileID, e.Quality.Quality.ID)
+						// Counted per file: new albums of older artists are library growth too.
+						growth.addBytes(e.DateAdded, e.Size)
 					}
 					mu.Unlock()
 				}
@@ -167,6 +194,8 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 						if a.Monitored {
 							albumsMonitored++
 						}
+						downloaded := a.Statistics.TrackCount > 0 && a.Statistics.TrackFileCount >= a.Statistics.TrackCount
+						albumTypes[[2]string{a.AlbumType, strconv.FormatBool(downloaded)}]++
 						for _, genre := range s.Genres {
 							albumGenres[genre]++
 						}
@@ -183,17 +212,51 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	// Only totalRecords is read: request the smallest page the API allows.
-	// This total forces a full count, so it is skippable on huge instances.
-	var albumsMissing int
+	// These totals force full counts, so they are skippable on huge instances.
+	var albumsMissing, albumsCutoffUnmet int
 	if !collector.config.DisableWantedMetrics {
-		missingParams := client.QueryParams{}
-		missingParams.Add("pageSize", "1")
-		missing, err := client.Get[model.Missing](c, "wanted/missing", missingParams)
+		wantedParams := client.QueryParams{}
+		wantedParams.Add("pageSize", "1")
+		missing, err := client.Get[model.Missing](c, "wanted/missing", wantedParams)
 		if err != nil {
 			emitError(log, ch, collector.errorMetric, "Error getting missing albums", "error", err)
 			return
 		}
 		albumsMissing = missing.TotalRecords
+
+		cutoffUnmet, err := client.Get[model.CutoffUnmet](c, "wanted/cutoff", wantedParams)
+		if err != nil {
+			emitError(log, ch, collector.errorMetric, "Error getting cutoff unmet albums", "error", err)
+			return
+		}
+		albumsCutoffUnmet = cutoffUnmet.TotalRecords
+	}
+
+	tagObjects, err := client.Get[model.TagArtists](c, "tag/detail")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting tags", "error", err)
+		return
+	}
+
+	var items []itemSample
+	if collector.config.EnableItemMetrics {
+		allowed, err := resolveItemTags(c, collector.config)
+		if err != nil {
+			emitError(log, ch, collector.errorMetric, "Error getting tags for item metrics", "error", err)
+			return
+		}
+		samples := make([]itemSample, 0, len(artists))
+		for _, a := range artists {
+			samples = append(samples, itemSample{
+				ID:              a.ID,
+				Title:           a.ArtistName,
+				Tags:            a.Tags,
+				Missing:         a.Statistics.TrackCount - a.Statistics.TrackFileCount,
+				SizeOnDisk:      a.Statistics.SizeOnDisk,
+				PercentComplete: float64(a.Statistics.PercentOfTracks),
+			})
+		}
+		items = selectItems(samples, collector.config, allowed)
 	}
 
 	ch <- prometheus.MustNewConstMetric(collector.artistsMetric, prometheus.GaugeValue, float64(len(artists)))
@@ -202,6 +265,7 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(collector.albumsMetric, prometheus.GaugeValue, float64(albums))
 	if !collector.config.DisableWantedMetrics {
 		ch <- prometheus.MustNewConstMetric(collector.albumsMissingMetric, prometheus.GaugeValue, float64(albumsMissing))
+		ch <- prometheus.MustNewConstMetric(collector.albumsCutoffUnmetMetric, prometheus.GaugeValue, float64(albumsCutoffUnmet))
 	}
 	ch <- prometheus.MustNewConstMetric(collector.songsMetric, prometheus.GaugeValue, float64(songs))
 	ch <- prometheus.MustNewConstMetric(collector.songsDownloadedMetric, prometheus.GaugeValue, float64(songsDownloaded))
@@ -211,6 +275,9 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 			ch <- prometheus.MustNewConstMetric(collector.artistGenresMetric, prometheus.GaugeValue, float64(count), genre)
 		}
 	}
+	for _, tag := range tagObjects {
+		ch <- prometheus.MustNewConstMetric(collector.artistsTagsMetric, prometheus.GaugeValue, float64(len(tag.ArtistIDs)), tag.Label)
+	}
 
 	if collectAlbums {
 		ch <- prometheus.MustNewConstMetric(collector.albumsMonitoredMetric, prometheus.GaugeValue, float64(albumsMonitored))
@@ -220,10 +287,16 @@ func (collector *lidarrCollector) Collect(ch chan<- prometheus.Metric) {
 				ch <- prometheus.MustNewConstMetric(collector.albumsGenresMetric, prometheus.GaugeValue, float64(count), genre)
 			}
 		}
+		for labels, count := range albumTypes {
+			ch <- prometheus.MustNewConstMetric(collector.albumsTypeMetric, prometheus.GaugeValue, float64(count), labels[0], labels[1])
+		}
 	}
 	if collectQuality && len(songsQualities) > 0 {
 		for qualityName, count := range songsQualities {
 			ch <- prometheus.MustNewConstMetric(collector.songsQualitiesMetric, prometheus.GaugeValue, float64(count), qualityName, qualityWeights[qualityName])
 		}
 	}
+	collector.itemMetrics.collect(ch, items)
+	collector.qualityProfileMetrics.collect(ch, profileCounts, collectQuality)
+	collector.growthMetrics.collect(ch, growth, collectQuality)
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	client "github.com/onedr0p/exportarr/internal/arr/client"
 	"github.com/onedr0p/exportarr/internal/arr/config"
@@ -44,7 +45,23 @@ func TestLidarrCollect(t *testing.T) {
 			},
 			expectedMetricsFile: "expected_metrics_extended.txt",
 		},
+		{
+			name: "item_metrics",
+			config: &config.ArrConfig{
+				App:                   "lidarr",
+				APIVersion:            "v3",
+				DisableQualityMetrics: true,
+				DisableAlbumMetrics:   true,
+				EnableItemMetrics:     true,
+				ItemMetricsLimit:      1,
+				ItemMetricsSortBy:     "size",
+			},
+			expectedMetricsFile: "expected_metrics_items.txt",
+		},
 	}
+	// Pinned so the added-date windows in the fixtures are stable.
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts, err := newTestLidarrServer(t, func(_ http.ResponseWriter, r *http.Request) {
@@ -98,3 +115,31 @@ func TestLidarrCollect_FailureDoesntPanic(t *testing.T) {
 		assert.Error(t, err)
 	}, "Collecting metrics should not panic on failure")
 }
+
+// Missing and cutoff-unmet totals must not be queried when disabled, matching
+// sonarr and radarr.
+func TestLidarrCollect_DisableWantedMetrics(t *testing.T) {
+	ts, err := newTestLidarrServer(t, func(_ http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/wanted/") {
+			t.Errorf("wanted endpoint %q must not be queried when disabled", r.URL.Path)
+		}
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		App:                  "lidarr",
+		APIVersion:           "v3",
+		URL:                  ts.URL,
+		APIKey:               fixtures.APIKey,
+		DisableWantedMetrics: true,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewLidarrCollector(cl, config)
+
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(collector), 5)
+	assert.Equal(t, testutil.CollectAndCount(collector, "lidarr_albums_missing_total", "lidarr_albums_cutoff_unmet_total"), 0,
+		"wanted series must be absent when disabled")
+	assert.Equal(t, testutil.CollectAndCount(collector, "lidarr_collector_error"), 0)
+}
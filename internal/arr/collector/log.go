@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// logPageSize is how many log entries are requested per page.
+	logPageSize = 100
+	// maxLogPages bounds how far back a single scrape walks the log, so a
+	// flood of errors cannot turn one scrape into hundreds of requests.
+	maxLogPages = 10
+)
+
+// logCount is an accumulated number of log entries for one level and logger.
+type logCount struct {
+	Level  string
+	Logger string
+	Count  int
+}
+
+// mergeLogCounts folds a windowed log sample into the accumulated entry.
+func mergeLogCounts(prev, next logCount) logCount {
+	prev.Level = next.Level
+	prev.Logger = next.Logger
+	prev.Count += next.Count
+	return prev
+}
+
+type logCollector struct {
+	client            *client.Client
+	config            *config.ArrConfig    // App configuration
+	logCache          *statCache[logCount] // Cache of log entry counts
+	logWindow         *eventWindow         // How far the log has been read
+	logMessagesMetric *prometheus.Desc     // Total number of warn/error log entries
+	errorMetric       *prometheus.Desc     // Error Description for use with InvalidMetric
+}
+
+// NewLogCollector builds a collector counting warn and error log entries by
+// logger. Like prowlarr's stats, it reads only the entries written since the
+// previous scrape, so counters start at zero when the exporter starts.
+func NewLogCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
+	return &logCollector{
+		client:    httpClient,
+		config:    c,
+		logCache:  newStatCache(mergeLogCounts),
+		logWindow: newEventWindow(now()),
+		logMessagesMetric: newDesc(c.App, "log_messages_total",
+			"Total number of warn and error log entries by level and logger since the exporter started", []string{"level", "logger"}, c.URL),
+		errorMetric: newDesc(c.App, "log_collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+}
+
+func (collector *logCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.errorMetric
+	ch <- collector.logMessagesMetric
+}
+
+func (collector *logCollector) Collect(ch chan<- prometheus.Metric) {
+	log := slog.With("collector", "log")
+	defer recoverCollect(log, ch, collector.errorMetric)
+	c := collector.client
+
+	params := client.QueryParams{}
+	params.Add("pageSize", strconv.Itoa(logPageSize))
+	params.Add("sortKey", "time")
+	params.Add("sortDirection", "descending")
+	// "warn" returns warn and everything more severe.
+	params.Add("level", "warn")
+
+	_, truncated, err := walkNewest(collector.logWindow, logPageSize, maxLogPages,
+		func(page int) ([]model.LogRecord, int, error) {
+			params.Set("page", strconv.Itoa(page))
+			logs, err := client.Get[model.Log](c, "log", params)
+			if err != nil {
+				return nil, 0, fmt.Errorf("page %d: %w", page, err)
+			}
+			return logs.Records, logs.TotalRecords, nil
+		},
+		func(rec model.LogRecord) (int, time.Time) { return rec.ID, rec.Time },
+		func(rec model.LogRecord) {
+			level := strings.ToLower(rec.Level)
+			if level != "warn" && level != "error" && level != "fatal" {
+				return
+			}
+			collector.logCache.Update(level+"/"+rec.Logger, logCount{Level: level, Logger: rec.Logger, Count: 1})
+		})
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting log", "error", err)
+		return
+	}
+	if truncated {
+		log.Warn("Log window exceeds the page budget; older entries in it are not counted", "pages", maxLogPages)
+	}
+
+	for _, lc := range collector.logCache.Values() {
+		ch <- prometheus.MustNewConstMetric(collector.logMessagesMetric, prometheus.CounterValue, float64(lc.Count), lc.Level, lc.Logger)
+	}
+}
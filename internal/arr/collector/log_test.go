@@ -0,0 +1,120 @@
+package collector
+
+import (
+	"github.com/onedr0p/exportarr/internal/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/fixtures"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLogCollect(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config *config.ArrConfig
+		path   string
+	}{
+		{
+			name: "radarr",
+			config: &config.ArrConfig{
+				App:        "radarr",
+				APIVersion: "v3",
+			},
+			path: "/api/v3/log",
+		},
+		{
+			name: "sonarr",
+			config: &config.ArrConfig{
+				App:        "sonarr",
+				APIVersion: "v3",
+			},
+			path: "/api/v3/log",
+		},
+		{
+			name: "lidarr",
+			config: &config.ArrConfig{
+				App:        "lidarr",
+				APIVersion: "v1",
+			},
+			path: "/api/v1/log",
+		},
+		{
+			name: "prowlarr",
+			config: &config.ArrConfig{
+				App:        "prowlarr",
+				APIVersion: "v1",
+			},
+			path: "/api/v1/log",
+		},
+	}
+
+	// The exporter starts at midnight: the entry from the previous evening
+	// predates the window and must not be counted.
+	pinNow(t, time.Date(2023, 10, 13, 0, 0, 0, 0, time.UTC))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := fixtures.NewTestSharedServer(t, func(_ http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, tt.path)
+				assert.Equal(t, r.URL.Query().Get("level"), "warn")
+				assert.Equal(t, r.URL.Query().Get("sortDirection"), "descending")
+			})
+			assert.NoError(t, err)
+
+			defer ts.Close()
+
+			tt.config.URL = ts.URL
+			tt.config.APIKey = fixtures.APIKey
+
+			cl, err := client.NewClient(tt.config)
+			assert.NoError(t, err)
+			collector := NewLogCollector(cl, tt.config)
+
+			b, err := os.ReadFile(fixtures.CommonFixturesPath + "expected_log_metrics.txt")
+			assert.NoError(t, err)
+
+			expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+			expected = strings.ReplaceAll(expected, "APP", tt.config.App)
+
+			// The second scrape sees the same entries again; none of them are
+			// newer than the first scrape's window, so the counts must hold.
+			for scrape := 1; scrape <= 2; scrape++ {
+				f := strings.NewReader(expected)
+				assert.NotPanics(t, func() {
+					err = testutil.CollectAndCompare(collector, f)
+				})
+				assert.NoError(t, err, "scrape %d", scrape)
+			}
+		})
+	}
+}
+
+func TestLogCollect_FailureDoesntPanic(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:    ts.URL,
+		APIKey: fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewLogCollector(cl, config)
+
+	f := strings.NewReader("")
+
+	assert.NotPanics(t, func() {
+		err := testutil.CollectAndCompare(collector, f)
+		assert.Error(t, err)
+	}, "Collecting metrics should not panic on failure")
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// mediaInfoKey identifies one codec/dynamic-range/resolution/audio mix.
+type mediaInfoKey struct {
+	videoCodec, dynamicRange, resolution, audioCodec string
+}
+
+// mediaInfoCount accumulates the files sharing one mediaInfoKey.
+type mediaInfoCount struct {
+	files int
+	bytes int64
+}
+
+// mediaInfoCounts tallies files by their normalized media info.
+type mediaInfoCounts map[mediaInfoKey]*mediaInfoCount
+
+func (m mediaInfoCounts) add(mi model.MediaInfo, size int64) {
+	key := mediaInfoKey{
+		videoCodec:   orUnknown(mi.VideoCodec),
+		dynamicRange: mi.VideoDynamicRange,
+		resolution:   resolutionBucket(mi.Resolution),
+		audioCodec:   orUnknown(mi.AudioCodec),
+	}
+	// The apps report an empty dynamic range for SDR files.
+	if key.dynamicRange == "" {
+		key.dynamicRange = "SDR"
+	}
+	c, ok := m[key]
+	if !ok {
+		c = &mediaInfoCount{}
+		m[key] = c
+	}
+	c.files++
+	c.bytes += size
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// resolutionBucket normalizes a "WIDTHxHEIGHT" resolution into 2160p, 1080p,
+// 720p or sd. Width is checked alongside height so that scope releases
+// (1920x800) land in the bucket they were mastered for.
+func resolutionBucket(res string) string {
+	w, h, ok := strings.Cut(res, "x")
+	if !ok {
+		return "unknown"
+	}
+	width, err := strconv.Atoi(w)
+	if err != nil {
+		return "unknown"
+	}
+	height, err := strconv.Atoi(h)
+	if err != nil || width <= 0 || height <= 0 {
+		return "unknown"
+	}
+	switch {
+	case width >= 3200 || height >= 1800:
+		return "2160p"
+	case width >= 1800 || height >= 1000:
+		return "1080p"
+	case width >= 1200 || height >= 700:
+		return "720p"
+	default:
+		return "sd"
+	}
+}
+
+// mediaInfoMetrics holds the opt-in media info descriptors shared by the
+// radarr and sonarr collectors.
+type mediaInfoMetrics struct {
+	files *prometheus.Desc // Number of files by media info
+	bytes *prometheus.Desc // Size of files by media info
+}
+
+func newMediaInfoMetrics(app, url string) mediaInfoMetrics {
+	labels := []string{"video_codec", "video_dynamic_range", "resolution", "audio_codec"}
+	return mediaInfoMetrics{
+		files: newDesc(app, "mediainfo_files_total",
+			"Number of files by video codec, dynamic range, resolution bucket and audio codec", labels, url),
+		bytes: newDesc(app, "mediainfo_filesize_bytes",
+			"Size of files in bytes by video codec, dynamic range, resolution bucket and audio codec", labels, url),
+	}
+}
+
+func (m mediaInfoMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- m.files
+	ch <- m.bytes
+}
+
+func (m mediaInfoMetrics) collect(ch chan<- prometheus.Metric, counts mediaInfoCounts) {
+	for key, c := range counts {
+		labels := []string{key.videoCodec, key.dynamicRange, key.resolution, key.audioCodec}
+		ch <- prometheus.MustNewConstMetric(m.files, prometheus.GaugeValue, float64(c.files), labels...)
+		ch <- prometheus.MustNewConstMetric(m.bytes, prometheus.GaugeValue, float64(c.bytes), labels...)
+	}
+}
@@ -0,0 +1,26 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/onedr0p/exportarr/internal/assert"
+)
+
+func TestResolutionBucket(t *testing.T) {
+	tests := map[string]string{
+		"3840x2160": "2160p",
+		"3840x1600": "2160p",
+		"1920x1080": "1080p",
+		"1920x800":  "1080p",
+		"1440x1080": "1080p",
+		"1280x720":  "720p",
+		"1280x536":  "720p",
+		"720x480":   "sd",
+		"":          "unknown",
+		"0x0":       "unknown",
+		"1920":      "unknown",
+	}
+	for in, want := range tests {
+		assert.Equal(t, resolutionBucket(in), want, in)
+	}
+}
@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"log/slog"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// healthIssueEvent is the event a connection needs to be told about health
+// issues, as named in model.Notification.Events.
+const healthIssueEvent = "healthIssue"
+
+type notificationCollector struct {
+	client                      *client.Client
+	config                      *config.ArrConfig // App configuration
+	notificationInfoMetric      *prometheus.Desc  // Connection metadata
+	eventEnabledMetric          *prometheus.Desc  // Whether each connection is subscribed to each event
+	failingMetric               *prometheus.Desc  // Whether each connection is failing
+	healthIssueConfiguredMetric *prometheus.Desc  // Whether any connection reports health issues, by level
+	errorMetric                 *prometheus.Desc  // Error Description for use with InvalidMetric
+}
+
+// NewNotificationCollector builds a collector for notification connections:
+// which events each one is subscribed to, and which ones the app reports as
+// failing in its NotificationStatusCheck health check.
+func NewNotificationCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
+	return &notificationCollector{
+		client: httpClient,
+		config: c,
+		notificationInfoMetric: newDesc(c.App, "notification_info",
+			"Notification connection information", []string{"connection", "implementation"}, c.URL),
+		eventEnabledMetric: newDesc(c.App, "notification_event_enabled",
+			"Whether the connection is subscribed to the event (1) or not (0)", []string{"connection", "event"}, c.URL),
+		failingMetric: newDesc(c.App, "notification_failing",
+			"Whether the connection is reported unavailable due to failures (1) or not (0)", []string{"connection"}, c.URL),
+		healthIssueConfiguredMetric: newDesc(c.App, "notification_health_issue_configured",
+			"Whether at least one connection is sent health issues of the level (1) or none is (0)", []string{"level"}, c.URL),
+		errorMetric: newDesc(c.App, "notification_collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+}
+
+func (collector *notificationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.errorMetric
+	ch <- collector.notificationInfoMetric
+	ch <- collector.eventEnabledMetric
+	ch <- collector.failingMetric
+	ch <- collector.healthIssueConfiguredMetric
+}
+
+func (collector *notificationCollector) Collect(ch chan<- prometheus.Metric) {
+	log := slog.With("collector", "notification")
+	defer recoverCollect(log, ch, collector.errorMetric)
+	c := collector.client
+
+	notifications, err := client.Get[model.Notifications](c, "notification")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting notifications", "error", err)
+		return
+	}
+	// Health only feeds notification_failing; the rest is reported without it.
+	health, healthErr := client.Get[model.SystemHealth](c, "health")
+	if healthErr != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting health", "error", healthErr)
+	}
+	failing := map[string]bool{}
+	for _, msg := range health {
+		if msg.Source == "NotificationStatusCheck" {
+			for _, name := range healthMessageNames(msg.Message) {
+				failing[name] = true
+			}
+		}
+	}
+
+	// A connection subscribed to health issues is only sent warnings when it
+	// includes them; errors always go out.
+	healthErrors, healthWarnings := false, false
+	for _, n := range notifications {
+		ch <- prometheus.MustNewConstMetric(collector.notificationInfoMetric, prometheus.GaugeValue, 1, n.Name, n.Implementation)
+		for event, subscribed := range n.Events {
+			ch <- prometheus.MustNewConstMetric(collector.eventEnabledMetric, prometheus.GaugeValue, boolToFloat(subscribed), n.Name, event)
+		}
+		if healthErr == nil {
+			ch <- prometheus.MustNewConstMetric(collector.failingMetric, prometheus.GaugeValue, boolToFloat(failing[n.Name] || failing[allUnavailable]), n.Name)
+		}
+		healthErrors = healthErrors || n.Events[healthIssueEvent]
+		healthWarnings = healthWarnings || (n.Events[healthIssueEvent] && n.IncludeHealthWarnings)
+	}
+	ch <- prometheus.MustNewConstMetric(collector.healthIssueConfiguredMetric, prometheus.GaugeValue, boolToFloat(healthErrors), "error")
+	ch <- prometheus.MustNewConstMetric(collector.healthIssueConfiguredMetric, prometheus.GaugeValue, boolToFloat(healthWarnings), "warning")
+}
@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/onedr0p/exportarr/internal/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	client "github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/onedr0p/exportarr/internal/fixtures"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNotificationCollect(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config *config.ArrConfig
+		prefix string
+	}{
+		{
+			name: "radarr",
+			config: &config.ArrConfig{
+				App:        "radarr",
+				APIVersion: "v3",
+			},
+			prefix: "/api/v3/",
+		},
+		{
+			name: "sonarr",
+			config: &config.ArrConfig{
+				App:        "sonarr",
+				APIVersion: "v3",
+			},
+			prefix: "/api/v3/",
+		},
+		{
+			name: "lidarr",
+			config: &config.ArrConfig{
+				App:        "lidarr",
+				APIVersion: "v1",
+			},
+			prefix: "/api/v1/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := fixtures.NewTestSharedServer(t, func(_ http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, tt.prefix)
+			})
+			assert.NoError(t, err)
+
+			defer ts.Close()
+
+			tt.config.URL = ts.URL
+			tt.config.APIKey = fixtures.APIKey
+
+			cl, err := client.NewClient(tt.config)
+			assert.NoError(t, err)
+			collector := NewNotificationCollector(cl, tt.config)
+
+			b, err := os.ReadFile(fixtures.CommonFixturesPath + "expected_notification_metrics.txt")
+			assert.NoError(t, err)
+
+			expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+			expected = strings.ReplaceAll(expected, "APP", tt.config.App)
+
+			f := strings.NewReader(expected)
+			assert.NotPanics(t, func() {
+				err = testutil.CollectAndCompare(collector, f)
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNotificationCollect_HealthFailure(t *testing.T) {
+	ts, err := fixtures.NewTestSharedServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/health") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+		App:        "sonarr",
+		APIVersion: "v3",
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewNotificationCollector(cl, config)
+
+	// Only notification_failing needs the health checks.
+	expected := strings.NewReader(fmt.Sprintf(`
+		# HELP sonarr_notification_collector_error Error while collecting metrics
+		# TYPE sonarr_notification_collector_error gauge
+		sonarr_notification_collector_error{url="%[1]s"} 1
+		# HELP sonarr_notification_info Notification connection information
+		# TYPE sonarr_notification_info gauge
+		sonarr_notification_info{connection="Discord",implementation="Discord",url="%[1]s"} 1
+		sonarr_notification_info{connection="Webhook",implementation="Webhook",url="%[1]s"} 1
+	`, ts.URL))
+	assert.NoError(t, testutil.CollectAndCompare(collector, expected,
+		"sonarr_notification_collector_error", "sonarr_notification_info", "sonarr_notification_failing"))
+}
+
+func TestNotificationCollect_FailureDoesntPanic(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:    ts.URL,
+		APIKey: fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewNotificationCollector(cl, config)
+
+	f := strings.NewReader("")
+
+	assert.NotPanics(t, func() {
+		err := testutil.CollectAndCompare(collector, f)
+		assert.Error(t, err)
+	}, "Collecting metrics should not panic on failure")
+}
+
+func TestNotification_UnmarshalEvents(t *testing.T) {
+	var n model.Notification
+	err := json.Unmarshal([]byte(`{
+		"name": "Discord",
+		"onGrab": true,
+		"onHealthIssue": false,
+		"supportsOnGrab": true,
+		"includeHealthWarnings": true,
+		"onlyName": "not a bool"
+	}`), &n)
+	assert.NoError(t, err)
+	assert.Equal(t, n.Name, "Discord")
+	assert.True(t, n.IncludeHealthWarnings)
+	assert.DeepEqual(t, n.Events, map[string]bool{"grab": true, "healthIssue": false})
+}
@@ -1,7 +1,11 @@
 package collector
 
 import (
+	"fmt"
 	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -9,9 +13,39 @@ import (
 	"github.com/onedr0p/exportarr/internal/arr/client"
 	"github.com/onedr0p/exportarr/internal/arr/config"
 	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/onedr0p/exportarr/internal/state"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	// historyPageSize is how many history entries are requested per page.
+	// Prowlarr logs every query, so its history is far denser than the log.
+	historyPageSize = 250
+	// maxHistoryPages bounds how far back a single scrape walks the history.
+	maxHistoryPages = 20
+)
+
+// historyQueryTypes maps the history event types that carry a query latency
+// to the type label of the latency histogram.
+var historyQueryTypes = map[string]string{
+	"indexerQuery": "search",
+	"indexerRss":   "rss",
+	"indexerAuth":  "auth",
+}
+
+// queryLatencyBuckets are the classic buckets of the query latency
+// histogram, in seconds. Scrapers that negotiate native histograms get the
+// exponential buckets instead.
+var queryLatencyBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// queryLatency is one indexer query read from the history.
+type queryLatency struct {
+	indexer   string
+	queryType string
+	success   bool
+	seconds   float64
+}
+
 // mergeIndexerStats folds a windowed indexer sample into the accumulated
 // entry: counters add up, the response time is the latest observation.
 func mergeIndexerStats(prev, next model.IndexerStats) model.IndexerStats {
@@ -37,40 +71,12 @@ func mergeUserAgentStats(prev, next model.UserAgentStats) model.UserAgentStats {
 	return prev
 }
 
-// UnavailableIndexerEmitter emits prowlarr_indexer_unavailable from indexer
-// status health messages.
-type UnavailableIndexerEmitter struct {
-	desc *prometheus.Desc
-}
-
-// NewUnavailableIndexerEmitter builds an emitter for the given prowlarr URL.
-func NewUnavailableIndexerEmitter(url string) *UnavailableIndexerEmitter {
-	return &UnavailableIndexerEmitter{
-		desc: newDesc("prowlarr", "indexer_unavailable", "Indexers marked unavailable due to repeated errors", []string{"indexer"}, url),
-	}
-}
-
-// Describe returns the emitter's metric descriptor.
-func (e *UnavailableIndexerEmitter) Describe() *prometheus.Desc {
-	return e.desc
-}
-
-// Emit derives unavailable-indexer metrics from a health message.
-func (e *UnavailableIndexerEmitter) Emit(msg model.SystemHealthMessage) []prometheus.Metric {
-	ret := []prometheus.Metric{}
-	if msg.Source == "IndexerStatusCheck" || msg.Source == "IndexerLongTermStatusCheck" {
-		parts := strings.Split(msg.Message, ":")
-		svrs := parts[len(parts)-1]
-		for svr := range strings.SplitSeq(svrs, ",") {
-			ret = append(ret, prometheus.MustNewConstMetric(
-				e.desc,
-				prometheus.GaugeValue,
-				1,
-				strings.TrimSpace(svr),
-			))
-		}
-	}
-	return ret
+// mergeHostStats folds a windowed host sample into the accumulated entry.
+func mergeHostStats(prev, next model.HostStats) model.HostStats {
+	prev.Host = next.Host
+	prev.NumberOfQueries += next.NumberOfQueries
+	prev.NumberOfGrabs += next.NumberOfGrabs
+	return prev
 }
 
 type prowlarrCollector struct {
@@ -78,10 +84,12 @@ type prowlarrCollector struct {
 	config                           *config.ArrConfig                // App configuration
 	indexerStatCache                 *statCache[model.IndexerStats]   // Cache of indexer stats
 	userAgentStatCache               *statCache[model.UserAgentStats] // Cache of user agent stats
+	hostStatCache                    *statCache[model.HostStats]      // Cache of host stats
 	statsMu                          sync.Mutex                       // Serializes the stats window so concurrent scrapes never double-count
 	lastStatUpdate                   time.Time                        // Last time stat caches were updated
 	indexerMetric                    *prometheus.Desc                 // Total number of configured indexers
 	indexerEnabledMetric             *prometheus.Desc                 // Total number of enabled indexers
+	indexerInfoMetric                *prometheus.Desc                 // Indexer protocol, privacy, priority, app profile and tags
 	indexerAverageResponseTimeMetric *prometheus.Desc                 // Average response time of indexers in ms
 	indexerQueriesMetric             *prometheus.Desc                 // Total number of queries
 	indexerGrabsMetric               *prometheus.Desc                 // Total number of grabs
@@ -95,21 +103,30 @@ type prowlarrCollector struct {
 	userAgentMetric                  *prometheus.Desc                 // Total number of active user agents
 	userAgentQueriesMetric           *prometheus.Desc                 // Total number of queries
 	userAgentGrabsMetric             *prometheus.Desc                 // Total number of grabs
+	queryLatencyMetric               *prometheus.HistogramVec         // Opt-in query latency by indexer, type and success
+	applicationInfoMetric            *prometheus.Desc                 // Application implementation and sync level
+	applicationEnabledMetric         *prometheus.Desc                 // Whether each application is synced
+	applicationBackoffMetric         *prometheus.Desc                 // Whether each application is disabled after failures
+	applicationEscalationMetric      *prometheus.Desc                 // Failure escalation level per application
+	applicationLastFailureMetric     *prometheus.Desc                 // Time of each application's most recent failure
+	applicationQueriesMetric         *prometheus.Desc                 // Total number of queries per application
+	applicationGrabsMetric           *prometheus.Desc                 // Total number of grabs per application
 	errorMetric                      *prometheus.Desc                 // Error Description for use with InvalidMetric
 
 }
 
 // NewProwlarrCollector builds a collector for prowlarr indexer statistics.
 func NewProwlarrCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
-	lastStatUpdate := time.Now()
+	lastStatUpdate := now()
 	if c.Prowlarr.Backfill || !c.Prowlarr.BackfillSinceTime.IsZero() {
 		lastStatUpdate = c.Prowlarr.BackfillSinceTime
 	}
-	return &prowlarrCollector{
+	collector := &prowlarrCollector{
 		client:                           httpClient,
 		config:                           c,
 		indexerStatCache:                 newStatCache(mergeIndexerStats),
 		userAgentStatCache:               newStatCache(mergeUserAgentStats),
+		hostStatCache:                    newStatCache(mergeHostStats),
 		lastStatUpdate:                   lastStatUpdate,
 		indexerMetric:                    newDesc("prowlarr", "indexer_total", "Total number of configured indexers", nil, c.URL),
 		indexerEnabledMetric:             newDesc("prowlarr", "indexer_enabled_total", "Total number of enabled indexers", nil, c.URL),
@@ -126,13 +143,85 @@ func NewProwlarrCollector(httpClient *client.Client, c *config.ArrConfig) promet
 		userAgentMetric:                  newDesc("prowlarr", "user_agent_total", "Total number of active user agents", nil, c.URL),
 		userAgentQueriesMetric:           newDesc("prowlarr", "user_agent_queries_total", "Total number of queries", []string{"user_agent"}, c.URL),
 		userAgentGrabsMetric:             newDesc("prowlarr", "user_agent_grabs_total", "Total number of grabs", []string{"user_agent"}, c.URL),
-		errorMetric:                      newDesc("prowlarr", "collector_error", "Error while collecting metrics", nil, c.URL),
+		indexerInfoMetric: newDesc("prowlarr", "indexer_info",
+			"Indexer metadata, for joining the per-indexer metrics on indexer to aggregate them by protocol, privacy and so on",
+			[]string{"indexer", "protocol", "privacy", "priority", "app_profile_id", "tags"}, c.URL),
+		applicationInfoMetric: newDesc("prowlarr", "application_info",
+			"Application prowlarr syncs indexers to, with its implementation and sync level", []string{"application", "implementation", "sync_level"}, c.URL),
+		applicationEnabledMetric: newDesc("prowlarr", "application_enabled",
+			"Whether prowlarr syncs indexers to the application (1) or its sync level is disabled (0)", []string{"application"}, c.URL),
+		applicationBackoffMetric: newDesc("prowlarr", "application_backoff",
+			"Whether the application is temporarily disabled after repeated sync failures (1) or not (0)", []string{"application"}, c.URL),
+		applicationEscalationMetric: newDesc("prowlarr", "application_escalation_level",
+			"Failure escalation level of the application; grows with consecutive failures and resets on success", []string{"application"}, c.URL),
+		applicationLastFailureMetric: newDesc("prowlarr", "application_last_failure_timestamp_seconds",
+			"Time of the application's most recent sync failure", []string{"application"}, c.URL),
+		applicationQueriesMetric: newDesc("prowlarr", "application_queries_total",
+			"Total number of queries made by the application", []string{"application"}, c.URL),
+		applicationGrabsMetric: newDesc("prowlarr", "application_grabs_total",
+			"Total number of grabs made by the application", []string{"application"}, c.URL),
+		queryLatencyMetric: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:                           "prowlarr_indexer_query_duration_seconds",
+			Help:                           "Latency of indexer queries read from the history since the exporter started",
+			ConstLabels:                    prometheus.Labels{"url": c.URL},
+			Buckets:                        queryLatencyBuckets,
+			NativeHistogramBucketFactor:    1.1,
+			NativeHistogramMaxBucketNumber: 100,
+		}, []string{"indexer", "type", "success"}),
+		errorMetric: newDesc("prowlarr", "collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+	if c.Prowlarr.StateFile != "" {
+		collector.restoreState()
+	}
+	return collector
+}
+
+// prowlarrState is what the state file holds: the accumulated stats and the
+// end of the window they cover. The latency histograms are not persisted.
+type prowlarrState struct {
+	LastStatUpdate time.Time                       `json:"lastStatUpdate"`
+	Indexers       map[string]model.IndexerStats   `json:"indexers"`
+	UserAgents     map[string]model.UserAgentStats `json:"userAgents"`
+	Hosts          map[string]model.HostStats      `json:"hosts"`
+}
+
+// restoreState loads the state file, if there is one. Restored stats take
+// precedence over backfill, which would otherwise count them a second time.
+// An unreadable state file is logged and the collector starts fresh.
+func (collector *prowlarrCollector) restoreState() {
+	path := collector.config.Prowlarr.StateFile
+	var st prowlarrState
+	ok, err := state.Load(path, &st)
+	if err != nil {
+		slog.Warn("Ignoring unreadable prowlarr state file", "path", path, "error", err)
+		return
 	}
+	if !ok {
+		return
+	}
+	collector.indexerStatCache.Restore(st.Indexers)
+	collector.userAgentStatCache.Restore(st.UserAgents)
+	collector.hostStatCache.Restore(st.Hosts)
+	collector.lastStatUpdate = st.LastStatUpdate
+	slog.Info("Restored prowlarr stats", "path", path, "since", st.LastStatUpdate)
+}
+
+// saveState writes the accumulated stats to the state file. The caller holds
+// statsMu, so the snapshot matches lastStatUpdate.
+func (collector *prowlarrCollector) saveState() error {
+	return state.Save(collector.config.Prowlarr.StateFile, prowlarrState{
+		LastStatUpdate: collector.lastStatUpdate,
+		Indexers:       collector.indexerStatCache.Snapshot(),
+		UserAgents:     collector.userAgentStatCache.Snapshot(),
+		Hosts:          collector.hostStatCache.Snapshot(),
+	})
 }
 
 func (collector *prowlarrCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.errorMetric
 	ch <- collector.indexerMetric
+	ch <- collector.indexerEnabledMetric
+	ch <- collector.indexerInfoMetric
 	ch <- collector.indexerAverageResponseTimeMetric
 	ch <- collector.indexerQueriesMetric
 	ch <- collector.indexerGrabsMetric
@@ -142,9 +231,20 @@ func (collector *prowlarrCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.indexerFailedGrabsMetric
 	ch <- collector.indexerFailedRssQueriesMetric
 	ch <- collector.indexerFailedAuthQueriesMetric
+	ch <- collector.indexerVipExpirationMetric
 	ch <- collector.userAgentMetric
 	ch <- collector.userAgentQueriesMetric
 	ch <- collector.userAgentGrabsMetric
+	ch <- collector.applicationInfoMetric
+	ch <- collector.applicationEnabledMetric
+	ch <- collector.applicationBackoffMetric
+	ch <- collector.applicationEscalationMetric
+	ch <- collector.applicationLastFailureMetric
+	ch <- collector.applicationQueriesMetric
+	ch <- collector.applicationGrabsMetric
+	if collector.config.Prowlarr.EnableQueryLatency {
+		collector.queryLatencyMetric.Describe(ch)
+	}
 }
 
 func (collector *prowlarrCollector) Collect(ch chan<- prometheus.Metric) {
@@ -160,10 +260,26 @@ func (collector *prowlarrCollector) Collect(ch chan<- prometheus.Metric) {
 		emitError(log, ch, collector.errorMetric, "Error getting indexers", "error", err)
 		return
 	}
+	// Tags only name the IDs on indexer_info; without them the IDs are
+	// shown as is rather than dropping every metric.
+	tags, err := client.Get[model.Tags](c, "tag")
+	if err != nil {
+		log.Warn("Error getting tags; labelling indexers with tag IDs", "error", err)
+	}
+	tagLabels := make(map[int]string, len(tags))
+	for _, tag := range tags {
+		tagLabels[tag.ID] = tag.Label
+	}
+	indexerNames := make(map[int]string, len(indexers))
 	for _, indexer := range indexers {
+		indexerNames[indexer.ID] = indexer.Name
 		if indexer.Enabled {
 			enabledIndexers++
 		}
+		ch <- prometheus.MustNewConstMetric(collector.indexerInfoMetric, prometheus.GaugeValue, 1,
+			indexer.Name, indexer.Protocol, indexer.Privacy, strconv.Itoa(indexer.Priority),
+			strconv.Itoa(indexer.AppProfileID), indexerTagLabels(indexer.Tags, tagLabels),
+		)
 
 		for _, field := range indexer.Fields {
 			if field.Name != "vipExpiration" {
@@ -188,7 +304,7 @@ func (collector *prowlarrCollector) Collect(ch chan<- prometheus.Metric) {
 	// here, both fetch the same window and the deltas get counted twice.
 	collector.statsMu.Lock()
 	startDate := collector.lastStatUpdate.In(time.UTC)
-	endDate := time.Now().In(time.UTC)
+	endDate := now().In(time.UTC)
 
 	params := client.QueryParams{}
 	params.Add("startDate", startDate.Format(time.RFC3339))
@@ -200,7 +316,21 @@ func (collector *prowlarrCollector) Collect(ch chan<- prometheus.Metric) {
 		emitError(log, ch, collector.errorMetric, "Error getting indexer stats", "error", err)
 		return
 	}
+	// Read the history over the same window before committing to it, so a
+	// failed read is retried in full next scrape.
+	var latencies []queryLatency
+	if collector.config.Prowlarr.EnableQueryLatency {
+		latencies, err = queryLatencies(log, c, startDate, endDate, indexerNames)
+		if err != nil {
+			collector.statsMu.Unlock()
+			emitError(log, ch, collector.errorMetric, "Error getting history", "error", err)
+			return
+		}
+	}
 	collector.lastStatUpdate = endDate
+	for _, l := range latencies {
+		collector.queryLatencyMetric.WithLabelValues(l.indexer, l.queryType, strconv.FormatBool(l.success)).Observe(l.seconds)
+	}
 
 	for _, istats := range stats.Indexers {
 		collector.indexerStatCache.Update(istats.Name, istats)
@@ -221,6 +351,16 @@ func (collector *prowlarrCollector) Collect(ch chan<- prometheus.Metric) {
 	for _, ustats := range stats.UserAgents {
 		collector.userAgentStatCache.Update(ustats.UserAgent, ustats)
 	}
+	for _, hstats := range stats.Hosts {
+		collector.hostStatCache.Update(hstats.Host, hstats)
+	}
+	// A failed write only costs continuity on the next restart, so it
+	// doesn't fail the scrape.
+	if collector.config.Prowlarr.StateFile != "" {
+		if err := collector.saveState(); err != nil {
+			log.Warn("Error saving prowlarr state", "error", err)
+		}
+	}
 	collector.statsMu.Unlock()
 
 	for _, custats := range collector.userAgentStatCache.Values() {
@@ -228,9 +368,170 @@ func (collector *prowlarrCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(collector.userAgentGrabsMetric, prometheus.GaugeValue, float64(custats.NumberOfGrabs), custats.UserAgent)
 	}
 
+	collector.collectApplications(log, ch)
+	if collector.config.Prowlarr.EnableQueryLatency {
+		collector.queryLatencyMetric.Collect(ch)
+	}
+
 	ch <- prometheus.MustNewConstMetric(collector.indexerMetric, prometheus.GaugeValue, float64(len(indexers)))
 	ch <- prometheus.MustNewConstMetric(collector.userAgentMetric, prometheus.GaugeValue, float64(len(stats.UserAgents)))
 	ch <- prometheus.MustNewConstMetric(collector.indexerEnabledMetric, prometheus.GaugeValue, float64(enabledIndexers))
 
 	log.Debug("Prowlarr cycle completed", "duration", time.Since(total))
 }
+
+// collectApplications emits the configured applications, their sync failure
+// state, and the queries and grabs each one made. A failed fetch only drops
+// these metrics; the indexer metrics don't depend on them.
+func (collector *prowlarrCollector) collectApplications(log *slog.Logger, ch chan<- prometheus.Metric) {
+	c := collector.client
+	applications, err := client.Get[model.Applications](c, "applications")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting applications", "error", err)
+		return
+	}
+	statuses, err := client.Get[model.ApplicationStatuses](c, "applications/status")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting application status", "error", err)
+		return
+	}
+
+	byProvider := make(map[int]int, len(statuses))
+	for i, s := range statuses {
+		byProvider[s.ProviderID] = i
+	}
+	hosts := map[string]model.HostStats{}
+	for _, h := range collector.hostStatCache.Values() {
+		hosts[strings.ToLower(h.Host)] = h
+	}
+	agents := collector.userAgentStatCache.Values()
+	implementations, appHosts := map[string]int{}, map[string]int{}
+	for _, a := range applications {
+		implementations[a.Implementation]++
+		appHosts[applicationHost(a)]++
+	}
+
+	current := now()
+	for _, a := range applications {
+		ch <- prometheus.MustNewConstMetric(collector.applicationInfoMetric, prometheus.GaugeValue, 1, a.Name, a.Implementation, a.SyncLevel)
+		ch <- prometheus.MustNewConstMetric(collector.applicationEnabledMetric, prometheus.GaugeValue, boolToFloat(a.SyncLevel != "disabled"), a.Name)
+
+		var escalation int
+		var backoff bool
+		if i, ok := byProvider[a.ID]; ok {
+			s := statuses[i]
+			escalation = s.EscalationLevel
+			backoff = s.DisabledTill.After(current)
+			if !s.MostRecentFailure.IsZero() {
+				ch <- prometheus.MustNewConstMetric(collector.applicationLastFailureMetric, prometheus.GaugeValue, float64(s.MostRecentFailure.Unix()), a.Name)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(collector.applicationEscalationMetric, prometheus.GaugeValue, float64(escalation), a.Name)
+		ch <- prometheus.MustNewConstMetric(collector.applicationBackoffMetric, prometheus.GaugeValue, boolToFloat(backoff), a.Name)
+
+		// Prefer the requests from the application's own host. Stats are
+		// keyed by the client's address, so a host shared with another
+		// application (one Docker host, a proxy) can't be split between
+		// them. Failing that, fall back to its user agent, but only when no
+		// other application shares the implementation and so the same user
+		// agent.
+		var queries, grabs int
+		host := applicationHost(a)
+		if h, ok := hosts[host]; ok && h.Host != "" && appHosts[host] == 1 {
+			queries, grabs = h.NumberOfQueries, h.NumberOfGrabs
+		} else if implementations[a.Implementation] == 1 {
+			for _, ua := range agents {
+				product, _, _ := strings.Cut(ua.UserAgent, "/")
+				if strings.EqualFold(product, a.Implementation) {
+					queries += ua.NumberOfQueries
+					grabs += ua.NumberOfGrabs
+				}
+			}
+		} else {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(collector.applicationQueriesMetric, prometheus.GaugeValue, float64(queries), a.Name)
+		ch <- prometheus.MustNewConstMetric(collector.applicationGrabsMetric, prometheus.GaugeValue, float64(grabs), a.Name)
+	}
+}
+
+// queryLatencies walks the history newest-first and returns the queries made
+// after since and up to until. Entries after until belong to the next window.
+func queryLatencies(log *slog.Logger, c *client.Client, since, until time.Time, indexerNames map[int]string) ([]queryLatency, error) {
+	var out []queryLatency
+	params := client.QueryParams{}
+	params.Add("pageSize", strconv.Itoa(historyPageSize))
+	params.Add("sortKey", "date")
+	params.Add("sortDirection", "descending")
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		history, err := client.Get[model.IndexerHistory](c, "history", params)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", page, err)
+		}
+		done := false
+		for _, rec := range history.Records {
+			if !rec.Date.After(since) {
+				done = true
+				break
+			}
+			queryType, ok := historyQueryTypes[rec.EventType]
+			if !ok || rec.Date.After(until) {
+				continue
+			}
+			elapsed, err := strconv.Atoi(rec.Data["elapsedTime"])
+			if err != nil {
+				continue
+			}
+			out = append(out, queryLatency{
+				indexer:   orUnknown(indexerNames[rec.IndexerID]),
+				queryType: queryType,
+				success:   rec.Successful,
+				seconds:   (time.Duration(elapsed) * time.Millisecond).Seconds(),
+			})
+		}
+		if done || len(history.Records) < historyPageSize || page*historyPageSize >= history.TotalRecords {
+			return out, nil
+		}
+		if page == maxHistoryPages {
+			// The stats window still moves on, so these queries are never
+			// recorded: the latency histograms sample at most this many
+			// queries per scrape, which a backfill easily exceeds.
+			log.Warn("History window exceeds the page budget; older queries in it are skipped", "pages", maxHistoryPages)
+			return out, nil
+		}
+	}
+}
+
+// indexerTagLabels renders an indexer's tags as a sorted, comma-separated
+// list of labels, so the series doesn't change with the order tags were added.
+// A tag without a known label is rendered as its ID.
+func indexerTagLabels(ids []int, labels map[int]string) string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		label, ok := labels[id]
+		if !ok {
+			label = strconv.Itoa(id)
+		}
+		out = append(out, label)
+	}
+	slices.Sort(out)
+	return strings.Join(out, ",")
+}
+
+// applicationHost returns the lowercased host of an application's baseUrl
+// field, or "" when it has none.
+func applicationHost(a model.Application) string {
+	for _, f := range a.Fields {
+		if f.Name != "baseUrl" {
+			continue
+		}
+		raw, _ := f.Value.(string)
+		u, err := url.Parse(raw)
+		if err != nil {
+			return ""
+		}
+		return strings.ToLower(u.Hostname())
+	}
+	return ""
+}
@@ -1,15 +1,30 @@
 package collector
 
 import (
+	"fmt"
 	"github.com/onedr0p/exportarr/internal/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	client "github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
 	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/onedr0p/exportarr/internal/fixtures"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
+const prowlarrTestFixturesPath = "../testdata/prowlarr/"
+
+func newTestProwlarrServer(t *testing.T, fn func(http.ResponseWriter, *http.Request)) (*httptest.Server, error) {
+	return fixtures.NewTestServer(t, prowlarrTestFixturesPath, fn)
+}
+
 type testCollector struct {
 	emitter ExtraHealthMetricEmitter
 	msg     model.SystemHealthMessage
@@ -26,7 +41,7 @@ func (c *testCollector) Collect(ch chan<- prometheus.Metric) {
 }
 
 func TestUnavailableIndexerEmitter(t *testing.T) {
-	emitter := NewUnavailableIndexerEmitter("http://localhost:9117")
+	emitter := NewUnavailableIndexerEmitter("prowlarr", "http://localhost:9117")
 	assert.NotNil(t, emitter.Describe())
 
 	msg := model.SystemHealthMessage{
@@ -54,3 +69,186 @@ func TestUnavailableIndexerEmitter(t *testing.T) {
 	err := testutil.CollectAndCompare(testCol, expected)
 	assert.NoError(t, err)
 }
+
+func TestProwlarrCollect(t *testing.T) {
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+	ts, err := newTestProwlarrServer(t, func(_ http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/api/v1/")
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+		App:        "prowlarr",
+		APIVersion: "v1",
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewProwlarrCollector(cl, config)
+
+	b, err := os.ReadFile(prowlarrTestFixturesPath + "expected_metrics.txt")
+	assert.NoError(t, err)
+	expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+
+	assert.NotPanics(t, func() {
+		err = testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	})
+	assert.NoError(t, err)
+}
+
+func TestProwlarrCollect_QueryLatency(t *testing.T) {
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+	ts, err := newTestProwlarrServer(t, func(_ http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/api/v1/")
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	// Backfill from the beginning so the fixture history falls in the window.
+	config := &config.ArrConfig{
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+		App:        "prowlarr",
+		APIVersion: "v1",
+		Prowlarr: config.ProwlarrConfig{
+			Backfill:           true,
+			EnableQueryLatency: true,
+		},
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewProwlarrCollector(cl, config)
+
+	b, err := os.ReadFile(prowlarrTestFixturesPath + "expected_latency_metrics.txt")
+	assert.NoError(t, err)
+	expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "prowlarr_indexer_query_duration_seconds"))
+}
+
+func TestProwlarrCollect_ApplicationsFailure(t *testing.T) {
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+	ts, err := newTestProwlarrServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/applications") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+		App:        "prowlarr",
+		APIVersion: "v1",
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewProwlarrCollector(cl, config)
+
+	expected := strings.NewReader(fmt.Sprintf(`
+		# HELP prowlarr_collector_error Error while collecting metrics
+		# TYPE prowlarr_collector_error gauge
+		prowlarr_collector_error{url="%[1]s"} 1
+		# HELP prowlarr_indexer_total Total number of configured indexers
+		# TYPE prowlarr_indexer_total gauge
+		prowlarr_indexer_total{url="%[1]s"} 2
+	`, ts.URL))
+	assert.NoError(t, testutil.CollectAndCompare(collector, expected,
+		"prowlarr_collector_error", "prowlarr_indexer_total", "prowlarr_application_info"))
+}
+
+func TestProwlarrCollect_TagFailure(t *testing.T) {
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+	ts, err := newTestProwlarrServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/tag") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+		App:        "prowlarr",
+		APIVersion: "v1",
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewProwlarrCollector(cl, config)
+
+	expected := strings.NewReader(fmt.Sprintf(`
+		# HELP prowlarr_indexer_info Indexer metadata, for joining the per-indexer metrics on indexer to aggregate them by protocol, privacy and so on
+		# TYPE prowlarr_indexer_info gauge
+		prowlarr_indexer_info{app_profile_id="1",indexer="NZBgeek",priority="10",privacy="private",protocol="usenet",tags="1,2",url="%[1]s"} 1
+		prowlarr_indexer_info{app_profile_id="2",indexer="1337x",priority="25",privacy="public",protocol="torrent",tags="",url="%[1]s"} 1
+	`, ts.URL))
+	assert.NoError(t, testutil.CollectAndCompare(collector, expected, "prowlarr_indexer_info", "prowlarr_collector_error"))
+}
+
+func TestProwlarrCollect_StateFile(t *testing.T) {
+	var startDates []string
+	ts, err := newTestProwlarrServer(t, func(_ http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/indexerstats") {
+			startDates = append(startDates, r.URL.Query().Get("startDate"))
+		}
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+		App:        "prowlarr",
+		APIVersion: "v1",
+		Prowlarr: config.ProwlarrConfig{
+			StateFile: filepath.Join(t.TempDir(), "prowlarr.json"),
+		},
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+
+	const queries = `
+		# HELP prowlarr_indexer_queries_total Total number of queries
+		# TYPE prowlarr_indexer_queries_total gauge
+		prowlarr_indexer_queries_total{indexer="1337x",url="%[1]s"} %[2]d
+		prowlarr_indexer_queries_total{indexer="NZBgeek",url="%[1]s"} %[3]d
+	`
+	first := time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC)
+	pinNow(t, first.Add(-time.Minute))
+	collector := NewProwlarrCollector(cl, config)
+	pinNow(t, first)
+	assert.NoError(t, testutil.CollectAndCompare(collector,
+		strings.NewReader(fmt.Sprintf(queries, ts.URL, 40, 160)), "prowlarr_indexer_queries_total"))
+
+	// A restarted exporter continues from the saved counters and window.
+	pinNow(t, first.Add(time.Hour))
+	restarted := NewProwlarrCollector(cl, config)
+	assert.NoError(t, testutil.CollectAndCompare(restarted,
+		strings.NewReader(fmt.Sprintf(queries, ts.URL, 80, 320)), "prowlarr_indexer_queries_total"))
+	assert.Len(t, startDates, 2)
+	assert.Equal(t, startDates[1], first.Format(time.RFC3339))
+}
+
+func TestProwlarrCollect_FailureDoesntPanic(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:    ts.URL,
+		APIKey: fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewProwlarrCollector(cl, config)
+
+	assert.NotPanics(t, func() {
+		err := testutil.CollectAndCompare(collector, strings.NewReader(""))
+		assert.Error(t, err)
+	}, "Collecting metrics should not panic on failure")
+}
@@ -0,0 +1,157 @@
+package collector
+
+import (
+	"log/slog"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type qualityProfileCollector struct {
+	client               *client.Client
+	config               *config.ArrConfig // App configuration
+	profileInfoMetric    *prometheus.Desc  // Profile metadata, including the cutoff quality
+	upgradeAllowedMetric *prometheus.Desc  // Whether upgrades are allowed per profile
+	errorMetric          *prometheus.Desc  // Error Description for use with InvalidMetric
+}
+
+// NewQualityProfileCollector builds a collector for quality profile
+// metadata. How much of the library each profile holds, and how much of it
+// sits below the cutoff, is counted by the library collectors from the items
+// and files they already fetch (see qualityProfileMetrics).
+func NewQualityProfileCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
+	return &qualityProfileCollector{
+		client: httpClient,
+		config: c,
+		profileInfoMetric: newDesc(c.App, "qualityprofile_info",
+			"Quality profile information, including its cutoff quality", []string{"quality_profile", "cutoff"}, c.URL),
+		upgradeAllowedMetric: newDesc(c.App, "qualityprofile_upgrade_allowed",
+			"Whether the quality profile allows upgrades (1) or not (0)", []string{"quality_profile"}, c.URL),
+		errorMetric: newDesc(c.App, "qualityprofile_collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+}
+
+func (collector *qualityProfileCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.errorMetric
+	ch <- collector.profileInfoMetric
+	ch <- collector.upgradeAllowedMetric
+}
+
+func (collector *qualityProfileCollector) Collect(ch chan<- prometheus.Metric) {
+	log := slog.With("collector", "qualityprofile")
+	defer recoverCollect(log, ch, collector.errorMetric)
+
+	profiles, err := client.Get[model.QualityProfiles](collector.client, "qualityprofile")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting quality profiles", "error", err)
+		return
+	}
+	for _, p := range profiles {
+		ch <- prometheus.MustNewConstMetric(collector.profileInfoMetric, prometheus.GaugeValue, 1, p.Name, newProfileRanking(p).cutoffName)
+		ch <- prometheus.MustNewConstMetric(collector.upgradeAllowedMetric, prometheus.GaugeValue, boolToFloat(p.UpgradeAllowed), p.Name)
+	}
+}
+
+// profileRanking ranks a profile's qualities by their position in the
+// profile, the same ordering the apps use for their own cutoff checks.
+type profileRanking struct {
+	ranks      map[int]int // Quality ID -> rank
+	cutoffRank int
+	cutoffName string
+}
+
+func newProfileRanking(p model.QualityProfile) profileRanking {
+	r := profileRanking{ranks: map[int]int{}, cutoffRank: -1}
+	for rank, item := range p.Items {
+		if len(item.Items) > 0 {
+			for _, q := range item.Items {
+				r.ranks[q.Quality.ID] = rank
+			}
+			if item.ID == p.Cutoff {
+				r.cutoffRank, r.cutoffName = rank, item.Name
+			}
+			continue
+		}
+		r.ranks[item.Quality.ID] = rank
+		if item.Quality.ID == p.Cutoff {
+			r.cutoffRank, r.cutoffName = rank, item.Quality.Name
+		}
+	}
+	return r
+}
+
+// belowCutoff reports whether a file of the given quality has yet to reach
+// the cutoff. Qualities the profile doesn't list are never counted.
+func (r profileRanking) belowCutoff(qualityID int) bool {
+	rank, ok := r.ranks[qualityID]
+	return ok && r.cutoffRank >= 0 && rank < r.cutoffRank
+}
+
+// profileStats accumulates the items and files assigned to one profile.
+type profileStats struct {
+	name        string
+	ranking     profileRanking
+	items       int
+	belowCutoff int
+}
+
+// profileTally counts library items and below-cutoff files per quality
+// profile. Callers fanning out over files serialize addFile themselves.
+type profileTally map[int]*profileStats
+
+func newProfileTally(profiles model.QualityProfiles) profileTally {
+	t := make(profileTally, len(profiles))
+	for _, p := range profiles {
+		t[p.ID] = &profileStats{name: p.Name, ranking: newProfileRanking(p)}
+	}
+	return t
+}
+
+// addItem records one library item. Items of unknown profiles are skipped.
+func (t profileTally) addItem(profileID int) {
+	if s, ok := t[profileID]; ok {
+		s.items++
+	}
+}
+
+// addFile records one file of an item in the given profile.
+func (t profileTally) addFile(profileID, qualityID int) {
+	if s, ok := t[profileID]; ok && s.ranking.belowCutoff(qualityID) {
+		s.belowCutoff++
+	}
+}
+
+// qualityProfileMetrics holds the per-profile descriptors the library
+// collectors share, so the counts come from the items and files they
+// already fetch instead of a second walk of the library.
+type qualityProfileMetrics struct {
+	items       *prometheus.Desc // Number of items per profile
+	belowCutoff *prometheus.Desc // Number of files below the profile cutoff
+}
+
+func newQualityProfileMetrics(app, url string) qualityProfileMetrics {
+	return qualityProfileMetrics{
+		items: newDesc(app, "qualityprofile_items_total",
+			"Number of library items assigned to the quality profile", []string{"quality_profile"}, url),
+		belowCutoff: newDesc(app, "qualityprofile_below_cutoff_total",
+			"Number of files whose quality ranks below the quality profile cutoff", []string{"quality_profile"}, url),
+	}
+}
+
+func (m qualityProfileMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- m.items
+	ch <- m.belowCutoff
+}
+
+// collect emits the tally. withFiles is false when the files were not
+// walked, in which case below-cutoff counts would read as zero.
+func (m qualityProfileMetrics) collect(ch chan<- prometheus.Metric, t profileTally, withFiles bool) {
+	for _, s := range t {
+		ch <- prometheus.MustNewConstMetric(m.items, prometheus.GaugeValue, float64(s.items), s.name)
+		if withFiles {
+			ch <- prometheus.MustNewConstMetric(m.belowCutoff, prometheus.GaugeValue, float64(s.belowCutoff), s.name)
+		}
+	}
+}
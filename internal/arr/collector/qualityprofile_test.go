@@ -0,0 +1,124 @@
+package collector
+
+import (
+	"github.com/onedr0p/exportarr/internal/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	client "github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/fixtures"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestQualityProfileCollect(t *testing.T) {
+	var tests = []struct {
+		name         string
+		config       *config.ArrConfig
+		fixturesPath string
+	}{
+		{
+			name: "radarr",
+			config: &config.ArrConfig{
+				App:        "radarr",
+				APIVersion: "v3",
+			},
+			fixturesPath: radarrTestFixturesPath,
+		},
+		{
+			name: "sonarr",
+			config: &config.ArrConfig{
+				App:        "sonarr",
+				APIVersion: "v3",
+			},
+			fixturesPath: sonarrTestFixturesPath,
+		},
+		{
+			name: "lidarr",
+			config: &config.ArrConfig{
+				App:        "lidarr",
+				APIVersion: "v3",
+			},
+			fixturesPath: lidarrTestFixturesPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := fixtures.NewTestServer(t, tt.fixturesPath, func(_ http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, "/api/v3/")
+			})
+			assert.NoError(t, err)
+
+			defer ts.Close()
+
+			tt.config.URL = ts.URL
+			tt.config.APIKey = fixtures.APIKey
+
+			cl, err := client.NewClient(tt.config)
+			assert.NoError(t, err)
+			collector := NewQualityProfileCollector(cl, tt.config)
+
+			b, err := os.ReadFile(tt.fixturesPath + "expected_qualityprofile_metrics.txt")
+			assert.NoError(t, err)
+
+			expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+			f := strings.NewReader(expected)
+
+			assert.NotPanics(t, func() {
+				err = testutil.CollectAndCompare(collector, f)
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestQualityProfileCollect_OnlyFetchesProfiles proves the collector adds a
+// single request per scrape: per-profile item and cutoff counts come from the
+// library collectors, which already walk the library and its files.
+func TestQualityProfileCollect_OnlyFetchesProfiles(t *testing.T) {
+	ts, err := fixtures.NewTestServer(t, sonarrTestFixturesPath, func(_ http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/api/v3/qualityprofile")
+	})
+	assert.NoError(t, err)
+
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		App:        "sonarr",
+		APIVersion: "v3",
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewQualityProfileCollector(cl, config)
+
+	assert.Equal(t, testutil.CollectAndCount(collector, "sonarr_qualityprofile_info"), 2)
+}
+
+func TestQualityProfileCollect_FailureDoesntPanic(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:    ts.URL,
+		APIKey: fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewQualityProfileCollector(cl, config)
+
+	f := strings.NewReader("")
+
+	assert.NotPanics(t, func() {
+		err := testutil.CollectAndCompare(collector, f)
+		assert.Error(t, err)
+	}, "Collecting metrics should not panic on failure")
+}
@@ -12,19 +12,27 @@ import (
 
 type radarrCollector struct {
 	client                 *client.Client
-	config                 *config.ArrConfig // App configuration
-	movieEdition           *prometheus.Desc  // Total number of movies with an `edition` set
-	movieMetric            *prometheus.Desc  // Total number of movies
-	movieDownloadedMetric  *prometheus.Desc  // Total number of downloaded movies
-	movieMonitoredMetric   *prometheus.Desc  // Total number of monitored movies
-	movieUnmonitoredMetric *prometheus.Desc  // Total number of unmonitored movies
-	movieWantedMetric      *prometheus.Desc  // Total number of wanted movies
-	movieMissingMetric     *prometheus.Desc  // Total number of missing movies
-	movieCutoffUnmetMetric *prometheus.Desc  // Total number of movies with cutoff unmet
-	movieQualitiesMetric   *prometheus.Desc  // Total number of movies by quality
-	movieFileSizeMetric    *prometheus.Desc  // Total fizesize of all movies in bytes
-	errorMetric            *prometheus.Desc  // Error Description for use with InvalidMetric
-	movieTagsMetric        *prometheus.Desc  // Total number of downloaded movies by tag
+	config                 *config.ArrConfig     // App configuration
+	movieEdition           *prometheus.Desc      // Total number of movies with an `edition` set
+	movieMetric            *prometheus.Desc      // Total number of movies
+	movieDownloadedMetric  *prometheus.Desc      // Total number of downloaded movies
+	movieMonitoredMetric   *prometheus.Desc      // Total number of monitored movies
+	movieUnmonitoredMetric *prometheus.Desc      // Total number of unmonitored movies
+	movieWantedMetric      *prometheus.Desc      // Total number of wanted movies
+	movieMissingMetric     *prometheus.Desc      // Total number of missing movies
+	movieCutoffUnmetMetric *prometheus.Desc      // Total number of movies with cutoff unmet
+	movieQualitiesMetric   *prometheus.Desc      // Total number of movies by quality
+	movieFileSizeMetric    *prometheus.Desc      // Total fizesize of all movies in bytes
+	errorMetric            *prometheus.Desc      // Error Description for use with InvalidMetric
+	movieTagsMetric        *prometheus.Desc      // Total number of downloaded movies by tag
+	movieStatusMetric      *prometheus.Desc      // Total number of movies by status, minimum availability and file
+	releasePassedMetric    *prometheus.Desc      // Total number of monitored movies released without a file
+	itemMetrics            itemMetrics           // Opt-in per-movie metrics
+	mediaInfoMetrics       mediaInfoMetrics      // Opt-in movie file media info breakdown
+	customFormatMetrics    customFormatMetrics   // Movie files by custom format and score
+	qualityProfileMetrics  qualityProfileMetrics // Movies and below-cutoff movie files per quality profile
+	growthMetrics          growthMetrics         // Movies and movie file bytes added per window, and movie age
+	tagMetrics             tagMetrics            // Size, monitored and missing movies by tag
 }
 
 // NewRadarrCollector builds a collector for radarr library statistics.
@@ -43,7 +51,17 @@ func NewRadarrCollector(httpClient *client.Client, c *config.ArrConfig) promethe
 		movieFileSizeMetric:    newDesc("radarr", "movie_filesize_total", "Total filesize of all movies", nil, c.URL),
 		movieQualitiesMetric:   newDesc("radarr", "movie_quality_total", "Total number of downloaded movies by quality", []string{"quality", "weight"}, c.URL),
 		movieTagsMetric:        newDesc("radarr", "movie_tag_total", "Total number of downloaded movies by tag", []string{"tag"}, c.URL),
-		errorMetric:            newDesc("radarr", "collector_error", "Error while collecting metrics", nil, c.URL),
+		movieStatusMetric: newDesc("radarr", "movie_status_total",
+			"Total number of movies by status, minimum availability and whether they have a file", []string{"status", "minimum_availability", "has_file"}, c.URL),
+		releasePassedMetric: newDesc("radarr", "movie_release_passed_missing_total",
+			"Total number of monitored movies whose digital or physical release date has passed without a file", []string{"release"}, c.URL),
+		itemMetrics:           newItemMetrics("radarr", "movie", c.URL),
+		mediaInfoMetrics:      newMediaInfoMetrics("radarr", c.URL),
+		customFormatMetrics:   newCustomFormatMetrics("radarr", c.URL),
+		qualityProfileMetrics: newQualityProfileMetrics("radarr", c.URL),
+		growthMetrics:         newGrowthMetrics("radarr", "movie", "movie", c.URL),
+		tagMetrics:            newTagMetrics("radarr", "movie", c.URL),
+		errorMetric:           newDesc("radarr", "collector_error", "Error while collecting metrics", nil, c.URL),
 	}
 }
 
@@ -60,6 +78,14 @@ func (collector *radarrCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.movieFileSizeMetric
 	ch <- collector.movieQualitiesMetric
 	ch <- collector.movieTagsMetric
+	ch <- collector.movieStatusMetric
+	ch <- collector.releasePassedMetric
+	collector.itemMetrics.describe(ch)
+	collector.mediaInfoMetrics.describe(ch)
+	collector.customFormatMetrics.describe(ch)
+	collector.qualityProfileMetrics.describe(ch)
+	collector.growthMetrics.describe(ch)
+	collector.tagMetrics.describe(ch)
 }
 
 func (collector *radarrCollector) Collect(ch chan<- prometheus.Metric) {
@@ -80,6 +106,12 @@ func (collector *radarrCollector) Collect(ch chan<- prometheus.Metric) {
 			Movies int
 		}{}
 		qualityWeights = map[string]string{}
+		statuses       = map[[3]string]int{}
+		digitalPassed  = 0
+		physicalPassed = 0
+		mediaInfo      = mediaInfoCounts{}
+		customFormats  = newCustomFormatTally()
+		taggedMovies   = map[int]tagItem{}
 	)
 
 	params := client.QueryParams{}
@@ -91,6 +123,13 @@ func (collector *radarrCollector) Collect(ch chan<- prometheus.Metric) {
 		emitError(log, ch, collector.errorMetric, "Error getting movies", "error", err)
 		return
 	}
+	profiles := getQualityProfiles(log, c)
+	profileIndex := profilesByID(profiles)
+	profileCounts := newProfileTally(profiles)
+	collectQuality := !collector.config.DisableQualityMetrics
+
+	current := now()
+	growth := newGrowthTally(current)
 	for _, s := range movies {
 		if s.HasFile {
 			downloaded++
@@ -116,6 +155,62 @@ func (collector *radarrCollector) Collect(ch chan<- prometheus.Metric) {
 		if s.MovieFile.Edition != "" {
 			editions++
 		}
+		if s.HasFile {
+			if collectQuality {
+				customFormats.add(s.MovieFile.CustomFormats, s.MovieFile.CustomFormatScore, profileIndex[s.QualityProfileID])
+			}
+			if collector.config.EnableMediaInfoMetrics {
+				mediaInfo.add(s.MovieFile.MediaInfo, s.MovieFile.Size)
+			}
+		}
+
+		// A movie's file arrives, and is upgraded, long after the movie is
+		// added, so its bytes count from when the file was added.
+		growth.addItem(s.Added)
+		if s.HasFile {
+			growth.addBytes(s.MovieFile.DateAdded, s.MovieFile.Size)
+		}
+		// Movies carry their single file inline, so the cutoff check is free.
+		profileCounts.addItem(s.QualityProfileID)
+		if s.HasFile {
+			profileCounts.addFile(s.QualityProfileID, s.MovieFile.Quality.Quality.ID)
+		}
+		tagged := tagItem{monitored: s.Monitored, sizeOnDisk: s.MovieFile.Size}
+		if s.Monitored && !s.HasFile && s.Available {
+			tagged.missing = 1
+		}
+		taggedMovies[s.ID] = tagged
+		statuses[[3]string{s.Status, s.MinimumAvailability, strconv.FormatBool(s.HasFile)}]++
+		// A monitored movie past its release date without a file is one we
+		// failed to grab, as opposed to one that simply isn't out yet.
+		if s.Monitored && !s.HasFile {
+			if !s.DigitalRelease.IsZero() && s.DigitalRelease.Before(current) {
+				digitalPassed++
+			}
+			if !s.PhysicalRelease.IsZero() && s.PhysicalRelease.Before(current) {
+				physicalPassed++
+			}
+		}
+	}
+
+	var items []itemSample
+	if collector.config.EnableItemMetrics {
+		allowed, err := resolveItemTags(c, collector.config)
+		if err != nil {
+			emitError(log, ch, collector.errorMetric, "Error getting tags for item metrics", "error", err)
+			return
+		}
+		samples := make([]itemSample, 0, len(movies))
+		for _, m := range movies {
+			sample := itemSample{ID: m.ID, Title: m.Title, Tags: m.Tags, SizeOnDisk: m.MovieFile.Size}
+			if m.HasFile {
+				sample.PercentComplete = 100
+			} else if m.Monitored && m.Available {
+				sample.Missing = 1
+			}
+			samples = append(samples, sample)
+		}
+		items = selectItems(samples, collector.config, allowed)
 	}
 
 	// https://radarr.video/docs/api/#/TagDetails/get_api_v3_tag_detail
@@ -187,5 +282,19 @@ func (collector *radarrCollector) Collect(ch chan<- prometheus.Metric) {
 			)
 		}
 	}
-
+	for _, s := range tagObjects {
+		collector.tagMetrics.collect(ch, s.Label, s.MovieIDs, taggedMovies)
+	}
+	for labels, count := range statuses {
+		ch <- prometheus.MustNewConstMetric(collector.movieStatusMetric, prometheus.GaugeValue, float64(count), labels[0], labels[1], labels[2])
+	}
+	ch <- prometheus.MustNewConstMetric(collector.releasePassedMetric, prometheus.GaugeValue, float64(digitalPassed), "digital")
+	ch <- prometheus.MustNewConstMetric(collector.releasePassedMetric, prometheus.GaugeValue, float64(physicalPassed), "physical")
+	collector.itemMetrics.collect(ch, items)
+	collector.mediaInfoMetrics.collect(ch, mediaInfo)
+	if collectQuality {
+		collector.customFormatMetrics.collect(ch, customFormats)
+	}
+	collector.qualityProfileMetrics.collect(ch, profileCounts, true)
+	collector.growthMetrics.collect(ch, growth, true)
 }
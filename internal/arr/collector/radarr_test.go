@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	client "github.com/onedr0p/exportarr/internal/arr/client"
 	"github.com/onedr0p/exportarr/internal/arr/config"
@@ -21,34 +22,74 @@ func newTestRadarrServer(t *testing.T, fn func(http.ResponseWriter, *http.Reques
 }
 
 func TestRadarrCollect(t *testing.T) {
-	ts, err := newTestRadarrServer(t, func(_ http.ResponseWriter, r *http.Request) {
-		assert.Contains(t, r.URL.Path, "/api/")
-	})
-	assert.NoError(t, err)
+	tests := []struct {
+		name                string
+		config              *config.ArrConfig
+		expectedMetricsFile string
+	}{
+		{
+			name: "basic",
+			config: &config.ArrConfig{
+				App:        "radarr",
+				APIVersion: "v3",
+			},
+			expectedMetricsFile: "expected_metrics.txt",
+		},
+		{
+			name: "item_metrics",
+			config: &config.ArrConfig{
+				App:               "radarr",
+				APIVersion:        "v3",
+				EnableItemMetrics: true,
+				ItemMetricsLimit:  2,
+				ItemMetricsSortBy: "size",
+				ItemMetricsTags:   []string{"somelabel"},
+			},
+			expectedMetricsFile: "expected_metrics_items.txt",
+		},
+		{
+			name: "media_info",
+			config: &config.ArrConfig{
+				App:                    "radarr",
+				APIVersion:             "v3",
+				EnableMediaInfoMetrics: true,
+			},
+			expectedMetricsFile: "expected_metrics_mediainfo.txt",
+		},
+	}
 
-	defer ts.Close()
+	// Movie 4's digital release (2023-09-01) has passed; its physical
+	// release (2023-11-20) has not.
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
 
-	config := &config.ArrConfig{
-		URL:        ts.URL,
-		App:        "radarr",
-		APIKey:     fixtures.APIKey,
-		APIVersion: "v3",
-	}
-	cl, err := client.NewClient(config)
-	assert.NoError(t, err)
-	collector := NewRadarrCollector(cl, config)
-	assert.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := newTestRadarrServer(t, func(_ http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, "/api/")
+			})
+			assert.NoError(t, err)
 
-	b, err := os.ReadFile(radarrTestFixturesPath + "expected_metrics.txt")
-	assert.NoError(t, err)
+			defer ts.Close()
 
-	expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
-	f := strings.NewReader(expected)
+			tt.config.URL = ts.URL
+			tt.config.APIKey = fixtures.APIKey
 
-	assert.NotPanics(t, func() {
-		err = testutil.CollectAndCompare(collector, f)
-	})
-	assert.NoError(t, err)
+			cl, err := client.NewClient(tt.config)
+			assert.NoError(t, err)
+			collector := NewRadarrCollector(cl, tt.config)
+
+			b, err := os.ReadFile(radarrTestFixturesPath + tt.expectedMetricsFile)
+			assert.NoError(t, err)
+
+			expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+			f := strings.NewReader(expected)
+
+			assert.NotPanics(t, func() {
+				err = testutil.CollectAndCompare(collector, f)
+			})
+			assert.NoError(t, err)
+		})
+	}
 }
 
 func TestRadarrCollect_FailureDoesntPanic(t *testing.T) {
@@ -73,3 +114,36 @@ func TestRadarrCollect_FailureDoesntPanic(t *testing.T) {
 		assert.Error(t, err)
 	}, "Collecting metrics should not panic on failure")
 }
+
+// TestRadarrCollect_QualityGating proves custom formats follow
+// DisableQualityMetrics like sonarr's, and that a failed quality profile
+// fetch only costs the profile label.
+func TestRadarrCollect_QualityGating(t *testing.T) {
+	ts, err := newTestRadarrServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/qualityprofile") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		App:        "radarr",
+		APIVersion: "v3",
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+
+	collector := NewRadarrCollector(cl, config)
+	assert.Equal(t, testutil.CollectAndCount(collector, "radarr_collector_error"), 0)
+	assert.Equal(t, testutil.CollectAndCount(collector, "radarr_movie_total"), 1)
+	assert.Equal(t, testutil.CollectAndCount(collector, "radarr_customformat_score_cutoff_delta"), 1,
+		"without profiles every file falls into the unknown profile")
+
+	config.DisableQualityMetrics = true
+	collector = NewRadarrCollector(cl, config)
+	assert.Equal(t, testutil.CollectAndCount(collector, "radarr_customformat_files_total", "radarr_customformat_score_cutoff_delta"), 0)
+	assert.Equal(t, testutil.CollectAndCount(collector, "radarr_movie_total"), 1)
+}
@@ -13,22 +13,34 @@ type rootFolderCollector struct {
 	client           *client.Client
 	config           *config.ArrConfig // App configuration
 	rootFolderMetric *prometheus.Desc  // Total number of root folders
+	accessibleMetric *prometheus.Desc  // Whether the root folder is reachable
+	totalSpaceMetric *prometheus.Desc  // Total space of the root folder's volume
+	unmappedMetric   *prometheus.Desc  // Number of unmapped folders in the root folder
 	errorMetric      *prometheus.Desc  // Error Description for use with InvalidMetric
 }
 
-// NewRootFolderCollector builds a collector for root-folder free space.
+// NewRootFolderCollector builds a collector for root-folder space,
+// accessibility and unmapped folders.
 func NewRootFolderCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
 	return &rootFolderCollector{
 		client:           httpClient,
 		config:           c,
 		rootFolderMetric: newDesc(c.App, "rootfolder_freespace_bytes", "Root folder space in bytes by path", []string{"path"}, c.URL),
-		errorMetric:      newDesc(c.App, "rootfolder_collector_error", "Error while collecting metrics", nil, c.URL),
+		accessibleMetric: newDesc(c.App, "rootfolder_accessible",
+			"Whether the root folder is accessible (1) or not (0) by path", []string{"path"}, c.URL),
+		totalSpaceMetric: newDesc(c.App, "rootfolder_totalspace_bytes", "Root folder total space in bytes by path", []string{"path"}, c.URL),
+		unmappedMetric: newDesc(c.App, "rootfolder_unmapped_folders_total",
+			"Number of folders in the root folder not mapped to any item by path", []string{"path"}, c.URL),
+		errorMetric: newDesc(c.App, "rootfolder_collector_error", "Error while collecting metrics", nil, c.URL),
 	}
 }
 
 func (collector *rootFolderCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.errorMetric
 	ch <- collector.rootFolderMetric
+	ch <- collector.accessibleMetric
+	ch <- collector.totalSpaceMetric
+	ch <- collector.unmappedMetric
 }
 
 func (collector *rootFolderCollector) Collect(ch chan<- prometheus.Metric) {
@@ -50,6 +62,9 @@ func (collector *rootFolderCollector) Collect(ch chan<- prometheus.Metric) {
 			ch <- prometheus.MustNewConstMetric(collector.rootFolderMetric, prometheus.GaugeValue, float64(rootFolder.FreeSpace),
 				rootFolder.Path,
 			)
+			ch <- prometheus.MustNewConstMetric(collector.accessibleMetric, prometheus.GaugeValue, boolToFloat(rootFolder.Accessible), rootFolder.Path)
+			ch <- prometheus.MustNewConstMetric(collector.totalSpaceMetric, prometheus.GaugeValue, float64(rootFolder.TotalSpace), rootFolder.Path)
+			ch <- prometheus.MustNewConstMetric(collector.unmappedMetric, prometheus.GaugeValue, float64(len(rootFolder.UnmappedFolders)), rootFolder.Path)
 		}
 	}
 }
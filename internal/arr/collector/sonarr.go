@@ -17,25 +17,49 @@ import (
 type sonarrCollector struct {
 	collectMu                sync.Mutex // Guards against overlapping collections (#380)
 	client                   *client.Client
-	config                   *config.ArrConfig // App configuration
-	seriesMetric             *prometheus.Desc  // Total number of series
-	seriesDownloadedMetric   *prometheus.Desc  // Total number of downloaded series
-	seriesMonitoredMetric    *prometheus.Desc  // Total number of monitored series
-	seriesUnmonitoredMetric  *prometheus.Desc  // Total number of unmonitored series
-	seriesFileSizeMetric     *prometheus.Desc  // Total fizesize of all series in bytes
-	seriesTagsMetric         *prometheus.Desc  // Total number of series by tag
-	seasonMetric             *prometheus.Desc  // Total number of seasons
-	seasonDownloadedMetric   *prometheus.Desc  // Total number of downloaded seasons
-	seasonMonitoredMetric    *prometheus.Desc  // Total number of monitored seasons
-	seasonUnmonitoredMetric  *prometheus.Desc  // Total number of unmonitored seasons
-	episodeMetric            *prometheus.Desc  // Total number of episodes
-	episodeMonitoredMetric   *prometheus.Desc  // Total number of monitored episodes
-	episodeUnmonitoredMetric *prometheus.Desc  // Total number of unmonitored episodes
-	episodeDownloadedMetric  *prometheus.Desc  // Total number of downloaded episodes
-	episodeMissingMetric     *prometheus.Desc  // Total number of missing episodes
-	episodeCutoffUnmetMetric *prometheus.Desc  // Total number of episodes with cutoff unmet
-	episodeQualitiesMetric   *prometheus.Desc  // Total number of episodes by quality
-	errorMetric              *prometheus.Desc  // Error Description for use with InvalidMetric
+	config                   *config.ArrConfig     // App configuration
+	seriesMetric             *prometheus.Desc      // Total number of series
+	seriesDownloadedMetric   *prometheus.Desc      // Total number of downloaded series
+	seriesMonitoredMetric    *prometheus.Desc      // Total number of monitored series
+	seriesUnmonitoredMetric  *prometheus.Desc      // Total number of unmonitored series
+	seriesFileSizeMetric     *prometheus.Desc      // Total fizesize of all series in bytes
+	seriesTagsMetric         *prometheus.Desc      // Total number of series by tag
+	seasonMetric             *prometheus.Desc      // Total number of seasons
+	seasonDownloadedMetric   *prometheus.Desc      // Total number of downloaded seasons
+	seasonMonitoredMetric    *prometheus.Desc      // Total number of monitored seasons
+	seasonUnmonitoredMetric  *prometheus.Desc      // Total number of unmonitored seasons
+	episodeMetric            *prometheus.Desc      // Total number of episodes
+	episodeMonitoredMetric   *prometheus.Desc      // Total number of monitored episodes
+	episodeUnmonitoredMetric *prometheus.Desc      // Total number of unmonitored episodes
+	episodeDownloadedMetric  *prometheus.Desc      // Total number of downloaded episodes
+	episodeMissingMetric     *prometheus.Desc      // Total number of missing episodes
+	episodeCutoffUnmetMetric *prometheus.Desc      // Total number of episodes with cutoff unmet
+	episodeQualitiesMetric   *prometheus.Desc      // Total number of episodes by quality
+	breakdownSeriesMetric    *prometheus.Desc      // Total number of series by status, type, profile and root folder
+	breakdownFilesMetric     *prometheus.Desc      // Total number of episode files by status, type, profile and root folder
+	breakdownFileSizeMetric  *prometheus.Desc      // Total filesize by status, type, profile and root folder
+	itemMetrics              itemMetrics           // Opt-in per-series metrics
+	mediaInfoMetrics         mediaInfoMetrics      // Opt-in episode file media info breakdown
+	customFormatMetrics      customFormatMetrics   // Episode files by custom format and score
+	qualityProfileMetrics    qualityProfileMetrics // Series and below-cutoff episode files per quality profile
+	growthMetrics            growthMetrics         // Series and episode file bytes added per window, and series age
+	tagMetrics               tagMetrics            // Size, monitored series and missing episodes by tag
+	errorMetric              *prometheus.Desc      // Error Description for use with InvalidMetric
+}
+
+// seriesBreakdownLabels are the dimensions series are broken down by, so
+// storage growth can be attributed to e.g. anime or daily shows.
+var seriesBreakdownLabels = []string{"status", "series_type", "quality_profile", "root_folder"}
+
+// seriesBreakdownKey identifies one seriesBreakdownLabels combination.
+type seriesBreakdownKey struct {
+	status, seriesType, qualityProfile, rootFolder string
+}
+
+// seriesBreakdown accumulates the series in one breakdown bucket.
+type seriesBreakdown struct {
+	series, episodeFiles int
+	sizeOnDisk           int64
 }
 
 // NewSonarrCollector builds a collector for sonarr library statistics.
@@ -60,7 +84,19 @@ func NewSonarrCollector(httpClient *client.Client, conf *config.ArrConfig) prome
 		episodeMissingMetric:     newDesc("sonarr", "episode_missing_total", "Total number of missing episodes", nil, conf.URL),
 		episodeCutoffUnmetMetric: newDesc("sonarr", "episode_cutoff_unmet_total", "Total number of episodes with cutoff unmet", nil, conf.URL),
 		episodeQualitiesMetric:   newDesc("sonarr", "episode_quality_total", "Total number of downloaded episodes by quality", []string{"quality", "weight"}, conf.URL),
-		errorMetric:              newDesc("sonarr", "collector_error", "Error while collecting metrics", nil, conf.URL),
+		breakdownSeriesMetric: newDesc("sonarr", "series_breakdown_total",
+			"Total number of series by status, series type, quality profile and root folder", seriesBreakdownLabels, conf.URL),
+		breakdownFilesMetric: newDesc("sonarr", "series_breakdown_episode_file_total",
+			"Total number of episode files by series status, series type, quality profile and root folder", seriesBreakdownLabels, conf.URL),
+		breakdownFileSizeMetric: newDesc("sonarr", "series_breakdown_filesize_bytes",
+			"Total filesize in bytes by series status, series type, quality profile and root folder", seriesBreakdownLabels, conf.URL),
+		itemMetrics:           newItemMetrics("sonarr", "series", conf.URL),
+		mediaInfoMetrics:      newMediaInfoMetrics("sonarr", conf.URL),
+		customFormatMetrics:   newCustomFormatMetrics("sonarr", conf.URL),
+		qualityProfileMetrics: newQualityProfileMetrics("sonarr", conf.URL),
+		growthMetrics:         newGrowthMetrics("sonarr", "series", "episode", conf.URL),
+		tagMetrics:            newTagMetrics("sonarr", "series", conf.URL),
+		errorMetric:           newDesc("sonarr", "collector_error", "Error while collecting metrics", nil, conf.URL),
 	}
 }
 
@@ -83,6 +119,15 @@ func (collector *sonarrCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.episodeMissingMetric
 	ch <- collector.episodeCutoffUnmetMetric
 	ch <- collector.episodeQualitiesMetric
+	ch <- collector.breakdownSeriesMetric
+	ch <- collector.breakdownFilesMetric
+	ch <- collector.breakdownFileSizeMetric
+	collector.itemMetrics.describe(ch)
+	collector.mediaInfoMetrics.describe(ch)
+	collector.customFormatMetrics.describe(ch)
+	collector.qualityProfileMetrics.describe(ch)
+	collector.growthMetrics.describe(ch)
+	collector.tagMetrics.describe(ch)
 }
 
 func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
@@ -119,6 +164,11 @@ func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
 		episodesUnmonitored = 0
 		episodesQualities   = map[string]int{}
 		qualityWeights      = map[string]string{}
+		breakdowns          = map[seriesBreakdownKey]*seriesBreakdown{}
+		mediaInfo           = mediaInfoCounts{}
+		customFormats       = newCustomFormatTally()
+		growth              = newGrowthTally(now())
+		taggedSeries        = map[int]tagItem{}
 	)
 
 	series, err := client.Get[model.Series](c, "series")
@@ -127,8 +177,13 @@ func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 
+	profiles := getQualityProfiles(log, c)
+	profileIndex := profilesByID(profiles)
+	profileCounts := newProfileTally(profiles)
+
 	collectQuality := !collector.config.DisableQualityMetrics
 	collectEpisodes := !collector.config.DisableEpisodeMetrics
+	collectMediaInfo := collector.config.EnableMediaInfoMetrics
 
 	// Quality definitions are repository-global: fetch once, not per series.
 	if collectQuality {
@@ -159,6 +214,32 @@ func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
 		episodes += s.Statistics.TotalEpisodeCount
 		episodesDownloaded += s.Statistics.EpisodeFileCount
 		seriesFileSize += s.Statistics.SizeOnDisk
+		growth.addItem(s.Added)
+		profileCounts.addItem(s.QualityProfileID)
+		// Like radarr, only monitored series count as missing files.
+		missing := 0
+		if s.Monitored {
+			missing = s.Statistics.EpisodeCount - s.Statistics.EpisodeFileCount
+		}
+		taggedSeries[s.ID] = tagItem{
+			monitored:  s.Monitored,
+			missing:    missing,
+			sizeOnDisk: s.Statistics.SizeOnDisk,
+		}
+
+		profile := "unknown"
+		if p, ok := profileIndex[s.QualityProfileID]; ok {
+			profile = p.Name
+		}
+		key := seriesBreakdownKey{s.Status, s.SeriesType, profile, s.RootFolderPath}
+		b, ok := breakdowns[key]
+		if !ok {
+			b = &seriesBreakdown{}
+			breakdowns[key] = b
+		}
+		b.series++
+		b.episodeFiles += s.Statistics.EpisodeFileCount
+		b.sizeOnDisk += s.Statistics.SizeOnDisk
 
 		for _, e := range s.Seasons {
 			if e.Monitored {
@@ -175,7 +256,7 @@ func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
 
 	// The per-series episode lookups dominate scrape time on large libraries:
 	// fan them out with bounded concurrency instead of ~2×N serial requests.
-	if collectQuality || collectEpisodes {
+	if collectQuality || collectEpisodes || collectMediaInfo {
 		var mu sync.Mutex
 		eg := errgroup.Group{}
 		eg.SetLimit(maxConcurrentSeriesFetches)
@@ -184,15 +265,26 @@ func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
 				params := client.QueryParams{}
 				params.Add("seriesId", strconv.Itoa(s.ID))
 
-				if collectQuality {
+				// Quality and media info share the one episodefile lookup.
+				if collectQuality || collectMediaInfo {
 					episodeFile, err := client.Get[model.EpisodeFile](c, "episodefile", params)
 					if err != nil {
 						return fmt.Errorf("getting episodefile for series %d: %w", s.ID, err)
 					}
 					mu.Lock()
 					for _, e := range episodeFile {
-						if e.Quality.Quality.Name != "" {
-							episodesQualities[e.Quality.Quality.Name]++
+						// Counted per file: new episodes of older series are
+						// library growth too.
+						growth.addBytes(e.DateAdded, e.Size)
+						if collectQuality {
+							if e.Quality.Quality.Name != "" {
+								episodesQualities[e.Quality.Quality.Name]++
+							}
+							customFormats.add(e.CustomFormats, e.CustomFormatScore, profileIndex[s.QualityProfileID])
+							profileCounts.addFile(s.QualityProfileID, e.Quality.Quality.ID)
+						}
+						if collectMediaInfo {
+							mediaInfo.add(e.MediaInfo, e.Size)
 						}
 					}
 					mu.Unlock()
@@ -246,6 +338,27 @@ func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
 		episodesCutoffUnmet = cutoffUnmet.TotalRecords
 	}
 
+	var items []itemSample
+	if collector.config.EnableItemMetrics {
+		allowed, err := resolveItemTags(c, collector.config)
+		if err != nil {
+			emitError(log, ch, collector.errorMetric, "Error getting tags for item metrics", "error", err)
+			return
+		}
+		samples := make([]itemSample, 0, len(series))
+		for _, s := range series {
+			samples = append(samples, itemSample{
+				ID:              s.ID,
+				Title:           s.Title,
+				Tags:            s.Tags,
+				Missing:         s.Statistics.EpisodeCount - s.Statistics.EpisodeFileCount,
+				SizeOnDisk:      s.Statistics.SizeOnDisk,
+				PercentComplete: float64(s.Statistics.PercentOfEpisodes),
+			})
+		}
+		items = selectItems(samples, collector.config, allowed)
+	}
+
 	// Get tag details for series
 	tagObjects, err := client.Get[model.TagSeries](c, "tag/detail")
 	if err != nil {
@@ -262,6 +375,13 @@ func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(collector.seriesTagsMetric, prometheus.GaugeValue, float64(len(tag.SeriesIDs)),
 			tag.Label,
 		)
+		collector.tagMetrics.collect(ch, tag.Label, tag.SeriesIDs, taggedSeries)
+	}
+	for key, b := range breakdowns {
+		labels := []string{key.status, key.seriesType, key.qualityProfile, key.rootFolder}
+		ch <- prometheus.MustNewConstMetric(collector.breakdownSeriesMetric, prometheus.GaugeValue, float64(b.series), labels...)
+		ch <- prometheus.MustNewConstMetric(collector.breakdownFilesMetric, prometheus.GaugeValue, float64(b.episodeFiles), labels...)
+		ch <- prometheus.MustNewConstMetric(collector.breakdownFileSizeMetric, prometheus.GaugeValue, float64(b.sizeOnDisk), labels...)
 	}
 	ch <- prometheus.MustNewConstMetric(collector.seasonMetric, prometheus.GaugeValue, float64(seasons))
 	ch <- prometheus.MustNewConstMetric(collector.seasonDownloadedMetric, prometheus.GaugeValue, float64(seasonsDownloaded))
@@ -285,6 +405,13 @@ func (collector *sonarrCollector) Collect(ch chan<- prometheus.Metric) {
 			)
 		}
 	}
+	collector.itemMetrics.collect(ch, items)
+	collector.mediaInfoMetrics.collect(ch, mediaInfo)
+	if collectQuality {
+		collector.customFormatMetrics.collect(ch, customFormats)
+	}
+	collector.qualityProfileMetrics.collect(ch, profileCounts, collectQuality)
+	collector.growthMetrics.collect(ch, growth, collectQuality || collectMediaInfo)
 	log.Debug("Sonarr cycle completed", "duration", time.Since(total))
 
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	client "github.com/onedr0p/exportarr/internal/arr/client"
 	"github.com/onedr0p/exportarr/internal/arr/config"
@@ -44,7 +45,35 @@ func TestSonarrCollect(t *testing.T) {
 			},
 			expectedMetricsFile: "expected_metrics_extended.txt",
 		},
+		{
+			name: "item_metrics",
+			config: &config.ArrConfig{
+				App:                   "sonarr",
+				APIVersion:            "v3",
+				DisableQualityMetrics: true,
+				DisableEpisodeMetrics: true,
+				EnableItemMetrics:     true,
+				ItemMetricsLimit:      2,
+				ItemMetricsSortBy:     "missing",
+				ItemMetricsTags:       []string{"Comedy"},
+			},
+			expectedMetricsFile: "expected_metrics_items.txt",
+		},
+		{
+			name: "media_info",
+			config: &config.ArrConfig{
+				App:                    "sonarr",
+				APIVersion:             "v3",
+				DisableQualityMetrics:  true,
+				DisableEpisodeMetrics:  true,
+				EnableMediaInfoMetrics: true,
+			},
+			expectedMetricsFile: "expected_metrics_mediainfo.txt",
+		},
 	}
+	// Pinned so the added-date windows in the fixtures are stable.
+	pinNow(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts, err := newTestSonarrServer(t, func(_ http.ResponseWriter, r *http.Request) {
@@ -127,3 +156,39 @@ func TestSonarrCollect_DisableWantedMetrics(t *testing.T) {
 		"wanted series must be absent when disabled")
 	assert.Equal(t, testutil.CollectAndCount(collector, "sonarr_collector_error"), 0)
 }
+
+// TestSonarrCollect_QualityProfileFailure proves a failed quality profile
+// fetch only costs the profile label, not the library metrics.
+func TestSonarrCollect_QualityProfileFailure(t *testing.T) {
+	ts, err := newTestSonarrServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/qualityprofile") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		App:                   "sonarr",
+		APIVersion:            "v3",
+		URL:                   ts.URL,
+		APIKey:                fixtures.APIKey,
+		DisableEpisodeMetrics: true,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewSonarrCollector(cl, config)
+
+	assert.Equal(t, testutil.CollectAndCount(collector, "sonarr_collector_error"), 0)
+	assert.Equal(t, testutil.CollectAndCount(collector, "sonarr_series_total"), 1)
+
+	expected := strings.ReplaceAll(`# HELP sonarr_series_breakdown_total Total number of series by status, series type, quality profile and root folder
+# TYPE sonarr_series_breakdown_total gauge
+sonarr_series_breakdown_total{quality_profile="unknown",root_folder="/anime/",series_type="anime",status="continuing",url="SOMEURL"} 2
+sonarr_series_breakdown_total{quality_profile="unknown",root_folder="/tv/",series_type="daily",status="continuing",url="SOMEURL"} 1
+sonarr_series_breakdown_total{quality_profile="unknown",root_folder="/tv/",series_type="standard",status="continuing",url="SOMEURL"} 1
+sonarr_series_breakdown_total{quality_profile="unknown",root_folder="/tv/",series_type="daily",status="ended",url="SOMEURL"} 1
+sonarr_series_breakdown_total{quality_profile="unknown",root_folder="/tv/",series_type="standard",status="ended",url="SOMEURL"} 1
+`, "SOMEURL", ts.URL)
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "sonarr_series_breakdown_total"))
+}
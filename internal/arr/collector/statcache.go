@@ -37,3 +37,18 @@ func (c *statCache[T]) Values() []T {
 	defer c.mu.Unlock()
 	return slices.Collect(maps.Values(c.data))
 }
+
+// Snapshot returns a copy of the accumulated entries by key, for persisting.
+func (c *statCache[T]) Snapshot() map[string]T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.data)
+}
+
+// Restore replaces the accumulated entries with a snapshot taken earlier.
+func (c *statCache[T]) Restore(data map[string]T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data = make(map[string]T, len(data))
+	maps.Copy(c.data, data)
+}
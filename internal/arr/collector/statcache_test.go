@@ -58,3 +58,22 @@ func TestStatCache_ConcurrentUpdates(t *testing.T) {
 
 	assert.DeepEqual(t, cache.Values(), []int{800})
 }
+
+func TestStatCache_SnapshotRestore(t *testing.T) {
+	cache := newStatCache(func(prev, next int) int { return prev + next })
+	cache.Update("a", 1)
+	cache.Update("b", 2)
+
+	snapshot := cache.Snapshot()
+	cache.Update("a", 10)
+	assert.DeepEqual(t, snapshot, map[string]int{"a": 1, "b": 2})
+
+	restored := newStatCache(func(prev, next int) int { return prev + next })
+	restored.Restore(snapshot)
+	restored.Update("a", 4)
+	assert.DeepEqual(t, restored.Snapshot(), map[string]int{"a": 5, "b": 2})
+
+	// Later changes to the restored snapshot don't leak into the cache.
+	snapshot["b"] = 100
+	assert.DeepEqual(t, restored.Snapshot(), map[string]int{"a": 5, "b": 2})
+}
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"log/slog"
+	"strconv"
 
 	"github.com/onedr0p/exportarr/internal/arr/client"
 	"github.com/onedr0p/exportarr/internal/arr/config"
@@ -10,25 +11,38 @@ import (
 )
 
 type systemStatusCollector struct {
-	client       *client.Client
-	config       *config.ArrConfig // App configuration
-	systemStatus *prometheus.Desc  // Total number of system statuses
-	errorMetric  *prometheus.Desc  // Error Description for use with InvalidMetric
+	client                *client.Client
+	config                *config.ArrConfig // App configuration
+	systemStatus          *prometheus.Desc  // Total number of system statuses
+	systemInfo            *prometheus.Desc  // Version, runtime and database details
+	startTimeMetric       *prometheus.Desc  // Start time of the app
+	updateAvailableMetric *prometheus.Desc  // Whether a newer version is installable
+	errorMetric           *prometheus.Desc  // Error Description for use with InvalidMetric
 }
 
-// NewSystemStatusCollector builds a collector for the system/status endpoint.
+// NewSystemStatusCollector builds a collector for the system/status and update
+// endpoints.
 func NewSystemStatusCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
 	return &systemStatusCollector{
 		client:       httpClient,
 		config:       c,
 		systemStatus: newDesc(c.App, "system_status", "System Status", nil, c.URL),
-		errorMetric:  newDesc(c.App, "status_collector_error", "Error while collecting metrics", nil, c.URL),
+		systemInfo: newDesc(c.App, "system_info",
+			"A metric with a constant '1' value labeled by version, branch, runtime, OS, database, authentication method and docker flag",
+			[]string{"version", "branch", "runtime_version", "os", "os_version", "database_type", "database_version", "authentication", "docker"}, c.URL),
+		startTimeMetric: newDesc(c.App, "start_time_seconds", "Start time of the app since unix epoch in seconds", nil, c.URL),
+		updateAvailableMetric: newDesc(c.App, "update_available",
+			"Whether a newer version than the running one is available to install (1) or not (0)", nil, c.URL),
+		errorMetric: newDesc(c.App, "status_collector_error", "Error while collecting metrics", nil, c.URL),
 	}
 }
 
 func (collector *systemStatusCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.errorMetric
 	ch <- collector.systemStatus
+	ch <- collector.systemInfo
+	ch <- collector.startTimeMetric
+	ch <- collector.updateAvailableMetric
 }
 
 func (collector *systemStatusCollector) Collect(ch chan<- prometheus.Metric) {
@@ -42,5 +56,30 @@ func (collector *systemStatusCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(collector.systemStatus, prometheus.GaugeValue, float64(0.0))
 	} else {
 		ch <- prometheus.MustNewConstMetric(collector.systemStatus, prometheus.GaugeValue, float64(1.0))
+		ch <- prometheus.MustNewConstMetric(collector.systemInfo, prometheus.GaugeValue, 1,
+			systemStatus.Version, systemStatus.Branch, systemStatus.RuntimeVersion,
+			systemStatus.OsName, systemStatus.OsVersion,
+			systemStatus.DatabaseType, systemStatus.DatabaseVersion,
+			systemStatus.Authentication, strconv.FormatBool(systemStatus.IsDocker),
+		)
+		if !systemStatus.StartTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(collector.startTimeMetric, prometheus.GaugeValue, float64(systemStatus.StartTime.Unix()))
+		}
 	}
+
+	// The update endpoint lists recent releases newest first; the newest one
+	// is flagged installable only when it is newer than the running version.
+	updates, err := client.Get[model.Updates](c, "update")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting updates", "error", err)
+		return
+	}
+	updateAvailable := false
+	for _, u := range updates {
+		if u.Latest && u.Installable {
+			updateAvailable = true
+			break
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(collector.updateAvailableMetric, prometheus.GaugeValue, boolToFloat(updateAvailable))
 }
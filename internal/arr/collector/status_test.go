@@ -26,7 +26,7 @@ func TestStatusCollect(t *testing.T) {
 				App:        "radarr",
 				APIVersion: "v3",
 			},
-			path: "/api/v3/system/status",
+			path: "/api/v3/",
 		},
 		{
 			name: "sonarr",
@@ -34,7 +34,7 @@ func TestStatusCollect(t *testing.T) {
 				App:        "sonarr",
 				APIVersion: "v3",
 			},
-			path: "/api/v3/system/status",
+			path: "/api/v3/",
 		},
 		{
 			name: "lidarr",
@@ -42,7 +42,7 @@ func TestStatusCollect(t *testing.T) {
 				App:        "lidarr",
 				APIVersion: "v1",
 			},
-			path: "/api/v1/system/status",
+			path: "/api/v1/",
 		},
 	}
 
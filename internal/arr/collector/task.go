@@ -0,0 +1,161 @@
+package collector
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/arr/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type systemTaskCollector struct {
+	client            *client.Client
+	config            *config.ArrConfig // App configuration
+	taskLastExecution *prometheus.Desc  // Last execution time per task
+	taskLastDuration  *prometheus.Desc  // Duration of the last run per task
+	taskInterval      *prometheus.Desc  // Configured interval per task
+	taskNextExecution *prometheus.Desc  // Next scheduled execution per task
+	taskOverdue       *prometheus.Desc  // Whether a task missed its schedule
+	commandMetric     *prometheus.Desc  // Queued/started commands by name and status
+	errorMetric       *prometheus.Desc  // Error Description for use with InvalidMetric
+}
+
+// NewSystemTaskCollector builds a collector for the system/task scheduler and
+// the command queue, so a task that silently stops running (RSS Sync, Refresh
+// Monitored Downloads) can be alerted on.
+func NewSystemTaskCollector(httpClient *client.Client, c *config.ArrConfig) prometheus.Collector {
+	return &systemTaskCollector{
+		client: httpClient,
+		config: c,
+		taskLastExecution: newDesc(c.App, "system_task_last_execution_timestamp_seconds",
+			"Time of the last execution of a scheduled task", []string{"task"}, c.URL),
+		taskLastDuration: newDesc(c.App, "system_task_last_duration_seconds",
+			"Duration of the last execution of a scheduled task", []string{"task"}, c.URL),
+		taskInterval: newDesc(c.App, "system_task_interval_seconds",
+			"Configured interval of a scheduled task (0 when disabled)", []string{"task"}, c.URL),
+		taskNextExecution: newDesc(c.App, "system_task_next_execution_timestamp_seconds",
+			"Time of the next scheduled execution of a task", []string{"task"}, c.URL),
+		taskOverdue: newDesc(c.App, "system_task_overdue",
+			"Whether a scheduled task has missed a full interval past its next execution (1) or not (0)", []string{"task"}, c.URL),
+		commandMetric: newDesc(c.App, "command_total",
+			"Number of queued or started commands by name and status", []string{"name", "status"}, c.URL),
+		errorMetric: newDesc(c.App, "task_collector_error", "Error while collecting metrics", nil, c.URL),
+	}
+}
+
+func (collector *systemTaskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.errorMetric
+	ch <- collector.taskLastExecution
+	ch <- collector.taskLastDuration
+	ch <- collector.taskInterval
+	ch <- collector.taskNextExecution
+	ch <- collector.taskOverdue
+	ch <- collector.commandMetric
+}
+
+func (collector *systemTaskCollector) Collect(ch chan<- prometheus.Metric) {
+	log := slog.With("collector", "system_task")
+	defer recoverCollect(log, ch, collector.errorMetric)
+	c := collector.client
+
+	tasks, err := client.Get[model.SystemTasks](c, "system/task")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting system tasks", "error", err)
+		return
+	}
+	current := now()
+	for _, task := range tasks {
+		name := task.TaskName
+		if name == "" {
+			name = task.Name
+		}
+		interval := time.Duration(task.Interval) * time.Minute
+		if !task.LastExecution.IsZero() {
+			ch <- prometheus.MustNewConstMetric(collector.taskLastExecution, prometheus.GaugeValue, float64(task.LastExecution.Unix()), name)
+		}
+		if d, err := parseTimeSpan(task.LastDuration); err != nil {
+			log.Debug("Skipping unparseable task duration", "task", name, "duration", task.LastDuration, "error", err)
+		} else {
+			ch <- prometheus.MustNewConstMetric(collector.taskLastDuration, prometheus.GaugeValue, d.Seconds(), name)
+		}
+		ch <- prometheus.MustNewConstMetric(collector.taskInterval, prometheus.GaugeValue, interval.Seconds(), name)
+		if !task.NextExecution.IsZero() {
+			ch <- prometheus.MustNewConstMetric(collector.taskNextExecution, prometheus.GaugeValue, float64(task.NextExecution.Unix()), name)
+		}
+		// A disabled task (interval 0) is never overdue; anything else is
+		// overdue once a whole interval has passed since it was due.
+		overdue := interval > 0 && !task.NextExecution.IsZero() && current.Sub(task.NextExecution) > interval
+		ch <- prometheus.MustNewConstMetric(collector.taskOverdue, prometheus.GaugeValue, boolToFloat(overdue), name)
+	}
+
+	// Tasks are what reveal a silently stopped RSS Sync, so a failed command
+	// fetch only drops the command counts.
+	commands, err := client.Get[model.Commands](c, "command")
+	if err != nil {
+		emitError(log, ch, collector.errorMetric, "Error getting commands", "error", err)
+		return
+	}
+	// The command endpoint also lists recently completed/failed commands;
+	// only the pending ones say anything about a stuck queue.
+	counts := map[[2]string]int{}
+	for _, cmd := range commands {
+		status := strings.ToLower(cmd.Status)
+		if status != "queued" && status != "started" {
+			continue
+		}
+		counts[[2]string{cmd.Name, status}]++
+	}
+	for labels, count := range counts {
+		ch <- prometheus.MustNewConstMetric(collector.commandMetric, prometheus.GaugeValue, float64(count), labels[0], labels[1])
+	}
+}
+
+// parseTimeSpan parses a .NET TimeSpan as serialized by the *arr APIs:
+// "[-][d.]hh:mm:ss[.fffffff]".
+func parseTimeSpan(s string) (time.Duration, error) {
+	raw := s
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	var days int
+	clock := s
+	// A day component precedes the first colon, separated by a dot.
+	if dot := strings.Index(s, "."); dot >= 0 && dot < strings.Index(s, ":") {
+		d, err := strconv.Atoi(s[:dot])
+		if err != nil {
+			return 0, fmt.Errorf("invalid timespan %q: %w", raw, err)
+		}
+		days, clock = d, s[dot+1:]
+	}
+
+	parts := strings.Split(clock, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid timespan %q", raw)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid timespan %q: %w", raw, err)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid timespan %q: %w", raw, err)
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timespan %q: %w", raw, err)
+	}
+
+	d := time.Duration(days)*24*time.Hour +
+		time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second))
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
@@ -0,0 +1,174 @@
+package collector
+
+import (
+	"fmt"
+	"github.com/onedr0p/exportarr/internal/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/onedr0p/exportarr/internal/arr/client"
+	"github.com/onedr0p/exportarr/internal/arr/config"
+	"github.com/onedr0p/exportarr/internal/fixtures"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemTaskCollect(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config *config.ArrConfig
+		path   string
+	}{
+		{
+			name: "radarr",
+			config: &config.ArrConfig{
+				App:        "radarr",
+				APIVersion: "v3",
+			},
+			path: "/api/v3/",
+		},
+		{
+			name: "sonarr",
+			config: &config.ArrConfig{
+				App:        "sonarr",
+				APIVersion: "v3",
+			},
+			path: "/api/v3/",
+		},
+		{
+			name: "lidarr",
+			config: &config.ArrConfig{
+				App:        "lidarr",
+				APIVersion: "v1",
+			},
+			path: "/api/v1/",
+		},
+		{
+			name: "prowlarr",
+			config: &config.ArrConfig{
+				App:        "prowlarr",
+				APIVersion: "v1",
+			},
+			path: "/api/v1/",
+		},
+	}
+
+	// RssSync was due at 20:15 and has missed far more than its 15m interval;
+	// RefreshMonitoredDownloads is 30s late, inside its 1m grace.
+	pinNow(t, time.Date(2023, 10, 14, 0, 1, 30, 0, time.UTC))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := fixtures.NewTestSharedServer(t, func(_ http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, tt.path)
+			})
+			assert.NoError(t, err)
+
+			defer ts.Close()
+
+			tt.config.URL = ts.URL
+			tt.config.APIKey = fixtures.APIKey
+
+			cl, err := client.NewClient(tt.config)
+			assert.NoError(t, err)
+			collector := NewSystemTaskCollector(cl, tt.config)
+
+			b, err := os.ReadFile(fixtures.CommonFixturesPath + "expected_task_metrics.txt")
+			assert.NoError(t, err)
+
+			expected := strings.ReplaceAll(string(b), "SOMEURL", ts.URL)
+			expected = strings.ReplaceAll(expected, "APP", tt.config.App)
+
+			f := strings.NewReader(expected)
+
+			assert.NotPanics(t, func() {
+				err = testutil.CollectAndCompare(collector, f)
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSystemTaskCollect_CommandFailure(t *testing.T) {
+	pinNow(t, time.Date(2023, 10, 14, 0, 1, 30, 0, time.UTC))
+	ts, err := fixtures.NewTestSharedServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/command") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:        ts.URL,
+		APIKey:     fixtures.APIKey,
+		App:        "sonarr",
+		APIVersion: "v3",
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewSystemTaskCollector(cl, config)
+
+	expected := strings.NewReader(fmt.Sprintf(`
+		# HELP sonarr_system_task_overdue Whether a scheduled task has missed a full interval past its next execution (1) or not (0)
+		# TYPE sonarr_system_task_overdue gauge
+		sonarr_system_task_overdue{task="ApplicationUpdateCheck",url="%[1]s"} 0
+		sonarr_system_task_overdue{task="Backup",url="%[1]s"} 0
+		sonarr_system_task_overdue{task="RefreshMonitoredDownloads",url="%[1]s"} 0
+		sonarr_system_task_overdue{task="RssSync",url="%[1]s"} 1
+		# HELP sonarr_task_collector_error Error while collecting metrics
+		# TYPE sonarr_task_collector_error gauge
+		sonarr_task_collector_error{url="%[1]s"} 1
+	`, ts.URL))
+	assert.NoError(t, testutil.CollectAndCompare(collector, expected,
+		"sonarr_system_task_overdue", "sonarr_task_collector_error", "sonarr_command_total"))
+}
+
+func TestSystemTaskCollect_FailureDoesntPanic(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	config := &config.ArrConfig{
+		URL:    ts.URL,
+		APIKey: fixtures.APIKey,
+	}
+	cl, err := client.NewClient(config)
+	assert.NoError(t, err)
+	collector := NewSystemTaskCollector(cl, config)
+
+	f := strings.NewReader("")
+
+	assert.NotPanics(t, func() {
+		err := testutil.CollectAndCompare(collector, f)
+		assert.Error(t, err)
+	}, "Collecting metrics should not panic on failure")
+}
+
+func TestParseTimeSpan(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"00:00:00", 0},
+		{"00:00:12.2500000", 12*time.Second + 250*time.Millisecond},
+		{"01:02:03", time.Hour + 2*time.Minute + 3*time.Second},
+		{"1.02:00:00", 26 * time.Hour},
+		{"-00:00:05", -5 * time.Second},
+	}
+	for _, tt := range tests {
+		got, err := parseTimeSpan(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, got, tt.want, tt.in)
+	}
+
+	for _, bad := range []string{"", "12", "aa:00:00", "1.2.3"} {
+		_, err := parseTimeSpan(bad)
+		assert.Error(t, err, bad)
+	}
+}
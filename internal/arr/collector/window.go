@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"sync"
+	"time"
+)
+
+// eventWindow tracks how far a newest-first paged endpoint (log, blocklist)
+// has been read, so each scrape counts only the entries added since the
+// previous one. Entries stamped with the same time as the watermark are told
+// apart by ID, so a burst written within one instant is neither dropped nor
+// counted twice.
+type eventWindow struct {
+	mu    sync.Mutex   // Serializes walks so concurrent scrapes never double-count
+	since time.Time    // Time of the newest entry counted so far
+	seen  map[int]bool // IDs of the counted entries stamped exactly since
+}
+
+func newEventWindow(since time.Time) *eventWindow {
+	return &eventWindow{since: since, seen: map[int]bool{}}
+}
+
+// walkNewest reads pages newest-first through fetch until it reaches entries
+// already counted, then passes every new entry to count. stamp returns an
+// entry's ID and time. At most maxPages pages are read per walk; truncated
+// reports that older entries in the window were left uncounted. A failed
+// fetch counts nothing and leaves the window where it was, so the next
+// scrape retries it. total is the record count the endpoint last reported.
+func walkNewest[T any](
+	w *eventWindow,
+	pageSize, maxPages int,
+	fetch func(page int) (records []T, total int, err error),
+	stamp func(T) (int, time.Time),
+	count func(T),
+) (total int, truncated bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	newest, newestSeen := w.since, map[int]bool{}
+	var fresh []T
+	for page := 1; ; page++ {
+		var records []T
+		records, total, err = fetch(page)
+		if err != nil {
+			return 0, false, err
+		}
+		done := false
+		for _, rec := range records {
+			id, t := stamp(rec)
+			if t.Before(w.since) {
+				done = true
+				break
+			}
+			if t.Equal(w.since) && w.seen[id] {
+				continue
+			}
+			fresh = append(fresh, rec)
+			switch {
+			case t.After(newest):
+				newest, newestSeen = t, map[int]bool{id: true}
+			case t.Equal(newest):
+				newestSeen[id] = true
+			}
+		}
+		if done || len(records) < pageSize || page*pageSize >= total {
+			break
+		}
+		if page == maxPages {
+			truncated = true
+			break
+		}
+	}
+
+	for _, rec := range fresh {
+		count(rec)
+	}
+	if newest.Equal(w.since) {
+		for id := range newestSeen {
+			w.seen[id] = true
+		}
+	} else {
+		w.since, w.seen = newest, newestSeen
+	}
+	return total, truncated, nil
+}
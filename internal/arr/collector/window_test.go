@@ -0,0 +1,100 @@
+package collector
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/onedr0p/exportarr/internal/assert"
+)
+
+type windowEntry struct {
+	id int
+	at time.Time
+}
+
+// walkEntries walks entries (newest first) in pages of two and returns the
+// IDs counted.
+func walkEntries(t *testing.T, w *eventWindow, entries []windowEntry, maxPages int) ([]int, bool) {
+	t.Helper()
+	var counted []int
+	_, truncated, err := walkNewest(w, 2, maxPages,
+		func(page int) ([]windowEntry, int, error) {
+			lo := min((page-1)*2, len(entries))
+			return entries[lo:min(lo+2, len(entries))], len(entries), nil
+		},
+		func(e windowEntry) (int, time.Time) { return e.id, e.at },
+		func(e windowEntry) { counted = append(counted, e.id) })
+	assert.NoError(t, err)
+	return counted, truncated
+}
+
+func TestWalkNewest_SameTimestampEntries(t *testing.T) {
+	t0 := time.Date(2023, 10, 13, 9, 0, 0, 0, time.UTC)
+	w := newEventWindow(t0.Add(-time.Minute))
+
+	counted, _ := walkEntries(t, w, []windowEntry{{2, t0}, {1, t0}}, 10)
+	assert.DeepEqual(t, counted, []int{2, 1})
+
+	// A third entry lands in the same instant as the watermark: it is
+	// counted, the two already seen are not.
+	counted, _ = walkEntries(t, w, []windowEntry{{3, t0}, {2, t0}, {1, t0}}, 10)
+	assert.DeepEqual(t, counted, []int{3})
+
+	counted, _ = walkEntries(t, w, []windowEntry{{4, t0.Add(time.Second)}, {3, t0}, {2, t0}, {1, t0}}, 10)
+	assert.DeepEqual(t, counted, []int{4})
+
+	counted, _ = walkEntries(t, w, []windowEntry{{4, t0.Add(time.Second)}, {3, t0}}, 10)
+	assert.Len(t, counted, 0)
+}
+
+func TestWalkNewest_StopsAtOlderEntries(t *testing.T) {
+	t0 := time.Date(2023, 10, 13, 9, 0, 0, 0, time.UTC)
+	w := newEventWindow(t0)
+
+	entries := []windowEntry{{5, t0.Add(3 * time.Second)}, {4, t0.Add(2 * time.Second)}, {3, t0.Add(time.Second)}, {2, t0.Add(-time.Second)}, {1, t0.Add(-2 * time.Second)}}
+	counted, truncated := walkEntries(t, w, entries, 10)
+	assert.DeepEqual(t, counted, []int{5, 4, 3})
+	assert.False(t, truncated)
+
+	counted, truncated = walkEntries(t, newEventWindow(time.Time{}), entries, 2)
+	assert.DeepEqual(t, counted, []int{5, 4, 3, 2})
+	assert.True(t, truncated)
+}
+
+func TestWalkNewest_FailedFetchCountsNothing(t *testing.T) {
+	t0 := time.Date(2023, 10, 13, 9, 0, 0, 0, time.UTC)
+	w := newEventWindow(t0)
+	entries := []windowEntry{{3, t0.Add(3 * time.Second)}, {2, t0.Add(2 * time.Second)}, {1, t0.Add(time.Second)}}
+
+	var counted []int
+	_, _, err := walkNewest(w, 2, 10,
+		func(page int) ([]windowEntry, int, error) {
+			if page == 2 {
+				return nil, 0, errors.New("boom")
+			}
+			return entries[:2], len(entries), nil
+		},
+		func(e windowEntry) (int, time.Time) { return e.id, e.at },
+		func(e windowEntry) { counted = append(counted, e.id) })
+	assert.Error(t, err)
+	assert.Len(t, counted, 0)
+
+	// The window did not move, so the retry counts every entry once.
+	counted, _ = walkEntries(t, w, entries, 10)
+	slices.Sort(counted)
+	assert.DeepEqual(t, counted, []int{1, 2, 3})
+}
+
+func TestWalkNewest_PanicReleasesLock(t *testing.T) {
+	w := newEventWindow(time.Time{})
+	func() {
+		defer func() { _ = recover() }()
+		_, _, _ = walkNewest(w, 2, 10,
+			func(int) ([]windowEntry, int, error) { panic("boom") },
+			func(e windowEntry) (int, time.Time) { return e.id, e.at },
+			func(windowEntry) {})
+	}()
+	assert.True(t, w.mu.TryLock(), "a panicking walk must not leave the window locked")
+}
@@ -28,27 +28,47 @@ func RegisterArrFlags(flags *flag.FlagSet) {
 	flags.Bool("disable-album-metrics", false, "Skip per-album metrics (lidarr album lookups; ~1 API call per artist each scrape)")
 	flags.Bool("disable-history-metrics", false, "Skip the history endpoint; its total forces a full count over the (unprunable) history table, which is slow on multi-year instances")
 	flags.Bool("disable-wanted-metrics", false, "Skip the wanted/missing and wanted/cutoff endpoints; their totals force full counts, which is slow on very large libraries")
+	flags.Bool("disable-import-list-metrics", false, "Skip the import list, import list status and exclusion endpoints")
+	flags.Bool("enable-health-messages", false, "Also export system_health_issues with the full health message and wiki URL as labels; churns series whenever a message changes")
+	flags.Bool("enable-log-metrics", false, "Count new warn/error log entries by logger, read incrementally from the log endpoint each scrape")
+	flags.Bool("enable-item-metrics", false, "Export per-series/movie/artist metrics labeled by title and ID, bounded by item-metrics-limit")
+	flags.Int("item-metrics-limit", 25, "Maximum number of items exported when item metrics are enabled")
+	flags.String("item-metrics-sort-by", "size", "Which items fill the item-metrics-limit budget: the largest on disk (size) or the most missing (missing)")
+	flags.StringSlice("item-metrics-tags", nil, "Only export item metrics for items carrying one of these tags")
+	flags.DurationSlice("calendar-windows", []time.Duration{24 * time.Hour, 7 * 24 * time.Hour}, "Windows ahead of now in which monitored calendar releases are counted")
+	flags.Duration("calendar-lookback", 7*24*time.Hour, "How far back the calendar is checked for monitored releases that still have no file")
+	flags.Bool("enable-media-info-metrics", false, "Count files and bytes by video codec, dynamic range, resolution and audio codec (sonarr: one episodefile lookup per series each scrape)")
 }
 
 // ArrConfig is the configuration for an *arr exporter.
 type ArrConfig struct {
-	App                     string         `env:"-"`
-	APIVersion              string         `env:"API_VERSION" envDefault:"v3"`
-	AuthUsername            string         `env:"AUTH_USERNAME"`
-	AuthPassword            string         `env:"AUTH_PASSWORD"`
-	FormAuth                bool           `env:"FORM_AUTH"`
-	EnableUnknownQueueItems bool           `env:"ENABLE_UNKNOWN_QUEUE_ITEMS"`
-	DisableQualityMetrics   bool           `env:"DISABLE_QUALITY_METRICS"`
-	DisableEpisodeMetrics   bool           `env:"DISABLE_EPISODE_METRICS"`
-	DisableAlbumMetrics     bool           `env:"DISABLE_ALBUM_METRICS"`
-	DisableHistoryMetrics   bool           `env:"DISABLE_HISTORY_METRICS"`
-	DisableWantedMetrics    bool           `env:"DISABLE_WANTED_METRICS"`
-	URL                     string         `env:"-"` // from the base config
-	APIKey                  string         `env:"-"` // from the base config
-	DisableSSLVerify        bool           `env:"-"` // from the base config
-	RequestTimeout          time.Duration  `env:"-"` // from the base config
-	Prowlarr                ProwlarrConfig `envPrefix:"PROWLARR__"`
-	Bazarr                  BazarrConfig   `envPrefix:"BAZARR__"`
+	App                      string          `env:"-"`
+	APIVersion               string          `env:"API_VERSION" envDefault:"v3"`
+	AuthUsername             string          `env:"AUTH_USERNAME"`
+	AuthPassword             string          `env:"AUTH_PASSWORD"`
+	FormAuth                 bool            `env:"FORM_AUTH"`
+	EnableUnknownQueueItems  bool            `env:"ENABLE_UNKNOWN_QUEUE_ITEMS"`
+	DisableQualityMetrics    bool            `env:"DISABLE_QUALITY_METRICS"`
+	DisableEpisodeMetrics    bool            `env:"DISABLE_EPISODE_METRICS"`
+	DisableAlbumMetrics      bool            `env:"DISABLE_ALBUM_METRICS"`
+	DisableHistoryMetrics    bool            `env:"DISABLE_HISTORY_METRICS"`
+	DisableWantedMetrics     bool            `env:"DISABLE_WANTED_METRICS"`
+	DisableImportListMetrics bool            `env:"DISABLE_IMPORT_LIST_METRICS"`
+	EnableHealthMessages     bool            `env:"ENABLE_HEALTH_MESSAGES"`
+	EnableLogMetrics         bool            `env:"ENABLE_LOG_METRICS"`
+	EnableItemMetrics        bool            `env:"ENABLE_ITEM_METRICS"`
+	ItemMetricsLimit         int             `env:"ITEM_METRICS_LIMIT" envDefault:"25"`
+	ItemMetricsSortBy        string          `env:"ITEM_METRICS_SORT_BY" envDefault:"size"`
+	ItemMetricsTags          []string        `env:"ITEM_METRICS_TAGS" envSeparator:","`
+	EnableMediaInfoMetrics   bool            `env:"ENABLE_MEDIA_INFO_METRICS"`
+	CalendarWindows          []time.Duration `env:"CALENDAR_WINDOWS" envSeparator:"," envDefault:"24h,168h"`
+	CalendarLookback         time.Duration   `env:"CALENDAR_LOOKBACK" envDefault:"168h"`
+	URL                      string          `env:"-"` // from the base config
+	APIKey                   string          `env:"-"` // from the base config
+	DisableSSLVerify         bool            `env:"-"` // from the base config
+	RequestTimeout           time.Duration   `env:"-"` // from the base config
+	Prowlarr                 ProwlarrConfig  `envPrefix:"PROWLARR__"`
+	Bazarr                   BazarrConfig    `envPrefix:"BAZARR__"`
 }
 
 // UseFormAuth reports whether form-based authentication is enabled.
@@ -85,6 +105,16 @@ func LoadArrConfig(conf base_config.Config, flags *flag.FlagSet) (*ArrConfig, er
 	base_config.OverlayFlag(flags, "disable-album-metrics", flags.GetBool, &out.DisableAlbumMetrics)
 	base_config.OverlayFlag(flags, "disable-history-metrics", flags.GetBool, &out.DisableHistoryMetrics)
 	base_config.OverlayFlag(flags, "disable-wanted-metrics", flags.GetBool, &out.DisableWantedMetrics)
+	base_config.OverlayFlag(flags, "disable-import-list-metrics", flags.GetBool, &out.DisableImportListMetrics)
+	base_config.OverlayFlag(flags, "enable-health-messages", flags.GetBool, &out.EnableHealthMessages)
+	base_config.OverlayFlag(flags, "enable-log-metrics", flags.GetBool, &out.EnableLogMetrics)
+	base_config.OverlayFlag(flags, "enable-item-metrics", flags.GetBool, &out.EnableItemMetrics)
+	base_config.OverlayFlag(flags, "item-metrics-limit", flags.GetInt, &out.ItemMetricsLimit)
+	base_config.OverlayFlag(flags, "item-metrics-sort-by", flags.GetString, &out.ItemMetricsSortBy)
+	base_config.OverlayFlag(flags, "item-metrics-tags", flags.GetStringSlice, &out.ItemMetricsTags)
+	base_config.OverlayFlag(flags, "enable-media-info-metrics", flags.GetBool, &out.EnableMediaInfoMetrics)
+	base_config.OverlayFlag(flags, "calendar-windows", flags.GetDurationSlice, &out.CalendarWindows)
+	base_config.OverlayFlag(flags, "calendar-lookback", flags.GetDuration, &out.CalendarLookback)
 	return out, nil
 }
 
@@ -107,5 +137,22 @@ func (c *ArrConfig) Validate() error {
 	} else if c.AuthUsername != "" || c.AuthPassword != "" {
 		errs = append(errs, errors.New("auth-username/auth-password are only supported with form-auth (basic auth was removed)"))
 	}
+
+	for _, w := range c.CalendarWindows {
+		if w <= 0 {
+			errs = append(errs, fmt.Errorf("calendar-windows must be positive durations: %s", w))
+		}
+	}
+	if c.CalendarLookback < 0 {
+		errs = append(errs, errors.New("calendar-lookback must not be negative"))
+	}
+	if c.EnableItemMetrics {
+		if c.ItemMetricsLimit < 1 {
+			errs = append(errs, errors.New("item-metrics-limit must be greater than zero"))
+		}
+		if c.ItemMetricsSortBy != "size" && c.ItemMetricsSortBy != "missing" {
+			errs = append(errs, fmt.Errorf("item-metrics-sort-by must be one of size, missing: %q", c.ItemMetricsSortBy))
+		}
+	}
 	return errors.Join(errs...)
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/onedr0p/exportarr/internal/assert"
 	"testing"
+	"time"
 
 	base_config "github.com/onedr0p/exportarr/internal/config"
 	"github.com/spf13/pflag"
@@ -44,6 +45,8 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, config.APIVersion, "v3")
+	assert.Equal(t, config.ItemMetricsLimit, 25)
+	assert.Equal(t, config.ItemMetricsSortBy, "size")
 
 	// base config values are not overwritten
 	assert.Equal(t, config.URL, "http://localhost")
@@ -63,6 +66,8 @@ func TestLoadConfig_Environment(t *testing.T) {
 	t.Setenv("FORM_AUTH", "true")
 	t.Setenv("ENABLE_UNKNOWN_QUEUE_ITEMS", "true")
 	t.Setenv("DISABLE_QUALITY_METRICS", "true")
+	t.Setenv("ITEM_METRICS_TAGS", "4k,kids")
+	t.Setenv("CALENDAR_WINDOWS", "12h,72h")
 
 	config, err := LoadArrConfig(c, flags)
 	assert.NoError(t, err)
@@ -72,6 +77,8 @@ func TestLoadConfig_Environment(t *testing.T) {
 	assert.True(t, config.FormAuth)
 	assert.True(t, config.EnableUnknownQueueItems)
 	assert.True(t, config.DisableQualityMetrics)
+	assert.DeepEqual(t, config.ItemMetricsTags, []string{"4k", "kids"})
+	assert.DeepEqual(t, config.CalendarWindows, []time.Duration{12 * time.Hour, 72 * time.Hour})
 
 	// defaults are not overwritten
 	assert.Equal(t, config.APIVersion, "v3")
@@ -117,6 +124,13 @@ func TestLoadConfig_Flags(t *testing.T) {
 	_ = flags.Set("form-auth", "true")
 	_ = flags.Set("enable-unknown-queue-items", "true")
 	_ = flags.Set("disable-episode-metrics", "true")
+	_ = flags.Set("enable-log-metrics", "true")
+	_ = flags.Set("item-metrics-limit", "10")
+	_ = flags.Set("item-metrics-sort-by", "missing")
+	_ = flags.Set("enable-media-info-metrics", "true")
+	_ = flags.Set("calendar-lookback", "48h")
+	_ = flags.Set("disable-import-list-metrics", "true")
+	_ = flags.Set("enable-health-messages", "true")
 	c := base_config.Config{}
 
 	// should be overridden by flags
@@ -128,6 +142,13 @@ func TestLoadConfig_Flags(t *testing.T) {
 	assert.True(t, config.FormAuth)
 	assert.True(t, config.EnableUnknownQueueItems)
 	assert.True(t, config.DisableEpisodeMetrics)
+	assert.True(t, config.EnableLogMetrics)
+	assert.Equal(t, config.ItemMetricsLimit, 10)
+	assert.Equal(t, config.ItemMetricsSortBy, "missing")
+	assert.True(t, config.EnableMediaInfoMetrics)
+	assert.Equal(t, config.CalendarLookback, 48*time.Hour)
+	assert.True(t, config.DisableImportListMetrics)
+	assert.True(t, config.EnableHealthMessages)
 
 	// defaults fall through
 	assert.Equal(t, config.APIVersion, "v3")
@@ -228,6 +249,51 @@ func TestValidate(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "good-item-metrics",
+			config: &ArrConfig{
+				URL:               "http://localhost",
+				APIKey:            "abcdef0123456789abcdef0123456789",
+				APIVersion:        "v3",
+				EnableItemMetrics: true,
+				ItemMetricsLimit:  25,
+				ItemMetricsSortBy: "missing",
+			},
+			valid: true,
+		},
+		{
+			name: "calendar-window-must-be-positive",
+			config: &ArrConfig{
+				URL:             "http://localhost",
+				APIKey:          "abcdef0123456789abcdef0123456789",
+				APIVersion:      "v3",
+				CalendarWindows: []time.Duration{24 * time.Hour, 0},
+			},
+			valid: false,
+		},
+		{
+			name: "item-metrics-needs-limit",
+			config: &ArrConfig{
+				URL:               "http://localhost",
+				APIKey:            "abcdef0123456789abcdef0123456789",
+				APIVersion:        "v3",
+				EnableItemMetrics: true,
+				ItemMetricsSortBy: "size",
+			},
+			valid: false,
+		},
+		{
+			name: "item-metrics-bad-sort",
+			config: &ArrConfig{
+				URL:               "http://localhost",
+				APIKey:            "abcdef0123456789abcdef0123456789",
+				APIVersion:        "v3",
+				EnableItemMetrics: true,
+				ItemMetricsLimit:  25,
+				ItemMetricsSortBy: "title",
+			},
+			valid: false,
+		},
 	}
 	for _, p := range params {
 		t.Run(p.name, func(t *testing.T) {
@@ -17,6 +17,11 @@ type ProwlarrConfig struct {
 	Backfill          bool   `env:"BACKFILL"`
 	BackfillSinceDate string `env:"BACKFILL_SINCE_DATE"`
 	BackfillSinceTime time.Time
+	// EnableQueryLatency reads the history each scrape to record per-query
+	// latency histograms.
+	EnableQueryLatency bool `env:"ENABLE_QUERY_LATENCY"`
+	// StateFile persists the accumulated stats across restarts when set.
+	StateFile string `env:"STATE_FILE"`
 }
 
 // RegisterProwlarrFlags registers prowlarr-specific flags on the given
@@ -24,6 +29,8 @@ type ProwlarrConfig struct {
 func RegisterProwlarrFlags(flags *flag.FlagSet) {
 	flags.Bool("backfill", false, "Backfill Prowlarr")
 	flags.String("backfill-since-date", "", "Date from which to start Prowlarr Backfill")
+	flags.Bool("enable-query-latency", false, "Read new history entries each scrape into per-indexer query latency histograms; at most 5000 per scrape, older ones are skipped")
+	flags.String("state-file", "", "File to persist accumulated Prowlarr stats in, so counters survive restarts")
 }
 
 // Validate checks the prowlarr configuration.
@@ -42,6 +49,8 @@ func (p ProwlarrConfig) Validate() error {
 func (c *ArrConfig) LoadProwlarrConfig(flags *flag.FlagSet) error {
 	base_config.OverlayFlag(flags, "backfill", flags.GetBool, &c.Prowlarr.Backfill)
 	base_config.OverlayFlag(flags, "backfill-since-date", flags.GetString, &c.Prowlarr.BackfillSinceDate)
+	base_config.OverlayFlag(flags, "enable-query-latency", flags.GetBool, &c.Prowlarr.EnableQueryLatency)
+	base_config.OverlayFlag(flags, "state-file", flags.GetString, &c.Prowlarr.StateFile)
 	if c.Prowlarr.BackfillSinceDate != "" {
 		t, err := time.Parse(backfillDateFormat, c.Prowlarr.BackfillSinceDate)
 		if err != nil {
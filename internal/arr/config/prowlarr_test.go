@@ -14,6 +14,8 @@ func TestLoadProwlarrConfig(t *testing.T) {
 
 	_ = flags.Set("backfill", "true")
 	_ = flags.Set("backfill-since-date", "2021-01-01")
+	_ = flags.Set("enable-query-latency", "true")
+	_ = flags.Set("state-file", "/data/prowlarr-state.json")
 	c := ArrConfig{
 		URL:              "http://localhost",
 		APIKey:           "abcdef0123456789abcdef0123456789",
@@ -23,6 +25,8 @@ func TestLoadProwlarrConfig(t *testing.T) {
 	assert.True(t, c.Prowlarr.Backfill)
 	assert.Equal(t, c.Prowlarr.BackfillSinceDate, "2021-01-01")
 	assert.Equal(t, c.Prowlarr.BackfillSinceTime.Format("2006-01-02"), "2021-01-01")
+	assert.True(t, c.Prowlarr.EnableQueryLatency)
+	assert.Equal(t, c.Prowlarr.StateFile, "/data/prowlarr-state.json")
 	assert.Equal(t, c.URL, "http://localhost")
 	assert.Equal(t, c.APIKey, "abcdef0123456789abcdef0123456789")
 	assert.True(t, c.DisableSSLVerify)
@@ -1,34 +1,56 @@
 package model
 
+import "time"
+
 // Artist - Stores struct of JSON response
 type Artist []struct {
 	ID         int    `json:"id"`
+	ArtistName string `json:"artistName"`
 	Status     string `json:"status"`
 	Ended      bool   `json:"ended"`
 	Monitored  bool   `json:"monitored"`
 	Statistics struct {
-		AlbumCount      int   `json:"albumCount"`
-		TrackFileCount  int   `json:"trackFileCount"`
-		TrackCount      int   `json:"trackCount"`
-		TotalTrackCount int   `json:"totalTrackCount"`
-		SizeOnDisk      int64 `json:"sizeOnDisk"`
+		AlbumCount      int     `json:"albumCount"`
+		TrackFileCount  int     `json:"trackFileCount"`
+		TrackCount      int     `json:"trackCount"`
+		TotalTrackCount int     `json:"totalTrackCount"`
+		SizeOnDisk      int64   `json:"sizeOnDisk"`
+		PercentOfTracks float32 `json:"percentOfTracks"`
 	} `json:"statistics"`
-	Genres           []string `json:"genres"`
-	QualityProfileID int      `json:"qualityProfileId"`
+	Genres           []string  `json:"genres"`
+	QualityProfileID int       `json:"qualityProfileId"`
+	Tags             []int     `json:"tags"`
+	Added            time.Time `json:"added"`
 }
 
 // Album - Stores struct of JSON response
 type Album []struct {
-	ID        int      `json:"id"`
-	Monitored bool     `json:"monitored"`
-	Genres    []string `json:"genres"`
-	Duration  int      `json:"duration"`
+	ID         int      `json:"id"`
+	Monitored  bool     `json:"monitored"`
+	Genres     []string `json:"genres"`
+	Duration   int      `json:"duration"`
+	AlbumType  string   `json:"albumType"`
+	Statistics struct {
+		TrackFileCount  int     `json:"trackFileCount"`
+		TrackCount      int     `json:"trackCount"`
+		TotalTrackCount int     `json:"totalTrackCount"`
+		SizeOnDisk      int64   `json:"sizeOnDisk"`
+		PercentOfTracks float32 `json:"percentOfTracks"`
+	} `json:"statistics"`
+}
+
+// TagArtists is the response from lidarr's tag/detail endpoint.
+type TagArtists []struct {
+	ID        int    `json:"id"`
+	Label     string `json:"label"`
+	ArtistIDs []int  `json:"artistIds"`
 }
 
 // SongFile - Stores struct of JSON response
 type SongFile []struct {
-	Size    int64 `json:"size"`
-	Quality struct {
+	Size      int64     `json:"size"`
+	DateAdded time.Time `json:"dateAdded"`
+	Quality   struct {
 		Quality struct {
 			ID   int    `json:"id"`
 			Name string `json:"name"`
@@ -1,11 +1,20 @@
 package model
 
+import "time"
+
 // Indexer is the response from prowlarr's indexer endpoint.
 type Indexer []struct {
+	ID       int    `json:"id"`
 	Name     string `json:"name"`
 	SortName string `json:"sortName"`
 	Enabled  bool   `json:"enable"`
-	Fields   []struct {
+	Protocol string `json:"protocol"`
+	// Privacy is "public", "private" or "semiPrivate".
+	Privacy      string `json:"privacy"`
+	Priority     int    `json:"priority"`
+	AppProfileID int    `json:"appProfileId"`
+	Tags         []int  `json:"tags"`
+	Fields       []struct {
 		Name string `json:"name"`
 		// Value has multiple types, depending on the field, so it
 		// must be typecast at the call site.
@@ -34,8 +43,60 @@ type UserAgentStats struct {
 	NumberOfGrabs   int    `json:"numberOfGrabs"`
 }
 
+// HostStats holds per-host query/grab counters, keyed by the address of the
+// client that made the requests.
+type HostStats struct {
+	Host            string `json:"host"`
+	NumberOfQueries int    `json:"numberOfQueries"`
+	NumberOfGrabs   int    `json:"numberOfGrabs"`
+}
+
 // IndexerStatResponse is the response from prowlarr's indexerstats endpoint.
 type IndexerStatResponse struct {
 	Indexers   []IndexerStats   `json:"indexers"`
 	UserAgents []UserAgentStats `json:"userAgents"`
+	Hosts      []HostStats      `json:"hosts"`
+}
+
+// Application is an *arr instance prowlarr syncs its indexers to.
+type Application struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Implementation string `json:"implementation"`
+	// SyncLevel is "disabled", "addOnly" or "fullSync".
+	SyncLevel string `json:"syncLevel"`
+	Fields    []struct {
+		Name  string `json:"name"`
+		Value any    `json:"value"`
+	} `json:"fields"`
+}
+
+// Applications is the response from prowlarr's applications endpoint.
+type Applications []Application
+
+// ApplicationStatuses is the response from prowlarr's applications/status
+// endpoint. Applications that have never failed have no entry.
+type ApplicationStatuses []struct {
+	ProviderID        int       `json:"providerId"`
+	MostRecentFailure time.Time `json:"mostRecentFailure"`
+	EscalationLevel   int       `json:"escalationLevel"`
+	DisabledTill      time.Time `json:"disabledTill"`
+}
+
+// IndexerHistory is a page of prowlarr's history endpoint.
+type IndexerHistory struct {
+	TotalRecords int                    `json:"totalRecords"`
+	Records      []IndexerHistoryRecord `json:"records"`
+}
+
+// IndexerHistoryRecord is one query, grab or auth event against an indexer.
+type IndexerHistoryRecord struct {
+	IndexerID  int       `json:"indexerId"`
+	Date       time.Time `json:"date"`
+	Successful bool      `json:"successful"`
+	// EventType is e.g. "indexerQuery", "indexerRss", "indexerAuth" or
+	// "releaseGrabbed".
+	EventType string `json:"eventType"`
+	// Data holds event details as strings, such as elapsedTime in ms.
+	Data map[string]string `json:"data"`
 }
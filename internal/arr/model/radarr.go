@@ -1,21 +1,37 @@
 package model
 
+import "time"
+
 // Movie - Stores struct of JSON response
 type Movie []struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
 	Status    string `json:"status"`
 	HasFile   bool   `json:"hasFile"`
 	Available bool   `json:"isAvailable"`
 	Monitored bool   `json:"monitored"`
-	MovieFile struct {
-		Edition string `json:"edition"`
-		Size    int64  `json:"size"`
-		Quality struct {
+	// MinimumAvailability is the status (announced/inCinemas/released) at
+	// which radarr starts searching for the movie.
+	MinimumAvailability string    `json:"minimumAvailability"`
+	DigitalRelease      time.Time `json:"digitalRelease"`
+	PhysicalRelease     time.Time `json:"physicalRelease"`
+	Added               time.Time `json:"added"`
+	MovieFile           struct {
+		Edition   string    `json:"edition"`
+		Size      int64     `json:"size"`
+		DateAdded time.Time `json:"dateAdded"`
+		Quality   struct {
 			Quality struct {
+				ID   int    `json:"id"`
 				Name string `json:"name"`
 			} `json:"quality"`
 		} `json:"quality"`
+		MediaInfo         MediaInfo      `json:"mediaInfo"`
+		CustomFormats     []CustomFormat `json:"customFormats"`
+		CustomFormatScore int            `json:"customFormatScore"`
 	} `json:"movieFile"`
-	QualityProfileID int `json:"qualityProfileId"`
+	QualityProfileID int   `json:"qualityProfileId"`
+	Tags             []int `json:"tags"`
 }
 
 // TagMovies is the response from radarr's tag/detail endpoint.
@@ -1,16 +1,113 @@
 package model
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
 // RootFolder - Stores struct of JSON response
 type RootFolder []struct {
-	Path      string `json:"path"`
-	FreeSpace int64  `json:"freeSpace"`
+	Path            string `json:"path"`
+	Accessible      bool   `json:"accessible"`
+	FreeSpace       int64  `json:"freeSpace"`
+	TotalSpace      int64  `json:"totalSpace"`
+	UnmappedFolders []struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
+	} `json:"unmappedFolders"`
+}
+
+// QualityProfiles - Stores struct of JSON response
+type QualityProfiles []QualityProfile
+
+// QualityProfile is one quality profile, resolved from the qualityProfileId
+// carried by library items.
+type QualityProfile struct {
+	ID                int                  `json:"id"`
+	Name              string               `json:"name"`
+	UpgradeAllowed    bool                 `json:"upgradeAllowed"`
+	Cutoff            int                  `json:"cutoff"` // ID of a quality or a quality group
+	Items             []QualityProfileItem `json:"items"`
+	CutoffFormatScore int                  `json:"cutoffFormatScore"`
+}
+
+// QualityProfileItem is either a single quality or a named group of
+// qualities; profile items are ordered from lowest to highest.
+type QualityProfileItem struct {
+	ID      int    `json:"id"`   // Group ID, zero for a single quality
+	Name    string `json:"name"` // Group name, empty for a single quality
+	Quality struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"quality"`
+	Items []QualityProfileItem `json:"items"`
+}
+
+// CustomFormat is a custom format matched by a movie or episode file.
+type CustomFormat struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// MediaInfo is the probed media information of a movie or episode file.
+type MediaInfo struct {
+	VideoCodec        string `json:"videoCodec"`
+	VideoDynamicRange string `json:"videoDynamicRange"`
+	Resolution        string `json:"resolution"`
+	AudioCodec        string `json:"audioCodec"`
+}
+
+// CalendarEntries - Stores struct of JSON response
+type CalendarEntries []CalendarEntry
+
+// CalendarEntry is an episode (sonarr), movie (radarr) or album (lidarr) from
+// the calendar endpoint; only the release dates of its own app are set.
+type CalendarEntry struct {
+	Monitored       bool      `json:"monitored"`
+	HasFile         bool      `json:"hasFile"`
+	AirDateUtc      time.Time `json:"airDateUtc"`
+	InCinemas       time.Time `json:"inCinemas"`
+	DigitalRelease  time.Time `json:"digitalRelease"`
+	PhysicalRelease time.Time `json:"physicalRelease"`
+	ReleaseDate     time.Time `json:"releaseDate"`
+	Statistics      struct {
+		TrackFileCount int `json:"trackFileCount"`
+		TrackCount     int `json:"trackCount"`
+	} `json:"statistics"`
+}
+
+// Tags - Stores struct of JSON response
+type Tags []struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
 }
 
 // SystemStatus - Stores struct of JSON response
 type SystemStatus struct {
-	Version string `json:"version"`
-	AppData string `json:"appData"`
-	Branch  string `json:"branch"`
+	Version         string    `json:"version"`
+	AppData         string    `json:"appData"`
+	Branch          string    `json:"branch"`
+	RuntimeVersion  string    `json:"runtimeVersion"`
+	OsName          string    `json:"osName"`
+	OsVersion       string    `json:"osVersion"`
+	DatabaseType    string    `json:"databaseType"`
+	DatabaseVersion string    `json:"databaseVersion"`
+	Authentication  string    `json:"authentication"`
+	IsDocker        bool      `json:"isDocker"`
+	StartTime       time.Time `json:"startTime"`
+}
+
+// Updates is the response from the shared update endpoint: recent releases,
+// newest first, flagged against the running version.
+type Updates []struct {
+	Version     string `json:"version"`
+	Branch      string `json:"branch"`
+	Installed   bool   `json:"installed"`
+	Installable bool   `json:"installable"`
+	Latest      bool   `json:"latest"`
 }
 
 // Queue - Stores struct of JSON response
@@ -77,3 +174,149 @@ type Qualities []struct {
 		Resolution int    `json:"resolution"`
 	}
 }
+
+// SystemTasks is the response from the shared system/task endpoint.
+type SystemTasks []SystemTask
+
+// SystemTask is one scheduled task reported by an *arr instance.
+// Interval is in minutes; LastDuration is a .NET TimeSpan string
+// ("[d.]hh:mm:ss[.fffffff]").
+type SystemTask struct {
+	Name          string    `json:"name"`
+	TaskName      string    `json:"taskName"`
+	Interval      int       `json:"interval"`
+	LastExecution time.Time `json:"lastExecution"`
+	LastDuration  string    `json:"lastDuration"`
+	NextExecution time.Time `json:"nextExecution"`
+}
+
+// Commands is the response from the shared command endpoint.
+type Commands []Command
+
+// Command is one queued, running or recently finished command.
+type Command struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Backups is the response from the shared system/backup endpoint.
+type Backups []struct {
+	Name string    `json:"name"`
+	Type string    `json:"type"`
+	Size int64     `json:"size"`
+	Time time.Time `json:"time"`
+}
+
+// Log is one page of the shared log endpoint.
+type Log struct {
+	Page         int         `json:"page"`
+	PageSize     int         `json:"pageSize"`
+	TotalRecords int         `json:"totalRecords"`
+	Records      []LogRecord `json:"records"`
+}
+
+// LogRecord is one application log entry.
+type LogRecord struct {
+	ID     int       `json:"id"`
+	Time   time.Time `json:"time"`
+	Level  string    `json:"level"`
+	Logger string    `json:"logger"`
+}
+
+// Blocklist is one page of the shared blocklist endpoint.
+type Blocklist struct {
+	Page         int               `json:"page"`
+	PageSize     int               `json:"pageSize"`
+	TotalRecords int               `json:"totalRecords"`
+	Records      []BlocklistRecord `json:"records"`
+}
+
+// BlocklistRecord is one blocklisted release.
+type BlocklistRecord struct {
+	ID       int       `json:"id"`
+	Date     time.Time `json:"date"`
+	Protocol string    `json:"protocol"`
+	Indexer  string    `json:"indexer"`
+}
+
+// QueueDetails is the response from the shared queue/details endpoint, which
+// unlike the paged queue also lists releases held back as pending.
+type QueueDetails []struct {
+	Status string    `json:"status"`
+	Added  time.Time `json:"added"`
+}
+
+// ImportLists is the response from the shared importlist endpoint.
+type ImportLists []struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Implementation string `json:"implementation"`
+	// Enabled and EnableAuto are radarr's; sonarr and lidarr lists have no
+	// separate on switch and only EnableAutomaticAdd.
+	Enabled            bool `json:"enabled"`
+	EnableAuto         bool `json:"enableAuto"`
+	EnableAutomaticAdd bool `json:"enableAutomaticAdd"`
+}
+
+// ImportListStatuses is the response from the shared importlist/status
+// endpoint. Lists that have never synced or failed have no entry.
+type ImportListStatuses []struct {
+	ProviderID        int       `json:"providerId"`
+	LastInfoSync      time.Time `json:"lastInfoSync"`
+	MostRecentFailure time.Time `json:"mostRecentFailure"`
+	EscalationLevel   int       `json:"escalationLevel"`
+	DisabledTill      time.Time `json:"disabledTill"`
+}
+
+// ImportListExclusions is the response from the importlistexclusion endpoint
+// (exclusions on radarr).
+type ImportListExclusions []struct {
+	ID int `json:"id"`
+}
+
+// Notifications is the response from the shared notification endpoint.
+type Notifications []Notification
+
+// Notification is one configured connection (Discord, webhook, email, ...).
+type Notification struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Implementation string `json:"implementation"`
+	// IncludeHealthWarnings is whether a connection subscribed to health
+	// issues is sent warnings too, rather than only errors.
+	IncludeHealthWarnings bool `json:"includeHealthWarnings"`
+	// Events maps each event the app offers to whether the connection is
+	// subscribed to it, keyed by the on* field without its prefix
+	// ("grab", "healthIssue"). The set of events differs per app and
+	// version, so it is read from the response rather than listed here.
+	Events map[string]bool `json:"-"`
+}
+
+// UnmarshalJSON decodes a notification and collects its on* event flags.
+func (n *Notification) UnmarshalJSON(data []byte) error {
+	type plain Notification
+	if err := json.Unmarshal(data, (*plain)(n)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	n.Events = map[string]bool{}
+	for key, raw := range fields {
+		event, ok := strings.CutPrefix(key, "on")
+		if !ok {
+			continue
+		}
+		first, size := utf8.DecodeRuneInString(event)
+		if !unicode.IsUpper(first) {
+			continue
+		}
+		var subscribed bool
+		if err := json.Unmarshal(raw, &subscribed); err != nil {
+			continue
+		}
+		n.Events[string(unicode.ToLower(first))+event[size:]] = subscribed
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 //
 // curl "http://localhost:8989/api/v3/$ENDPOINT?apiKey=$APIKEY"
 //
@@ -7,10 +9,17 @@ package model
 // Series - Stores struct of JSON response
 // https://github.com/Sonarr/Sonarr/wiki/Series
 type Series []struct {
-	ID         int       `json:"id"`
-	Monitored  bool      `json:"monitored"`
-	Seasons    []Seasons `json:"seasons"`
-	Statistics struct {
+	ID               int       `json:"id"`
+	Title            string    `json:"title"`
+	Monitored        bool      `json:"monitored"`
+	Status           string    `json:"status"`
+	SeriesType       string    `json:"seriesType"`
+	QualityProfileID int       `json:"qualityProfileId"`
+	RootFolderPath   string    `json:"rootFolderPath"`
+	Added            time.Time `json:"added"`
+	Tags             []int     `json:"tags"`
+	Seasons          []Seasons `json:"seasons"`
+	Statistics       struct {
 		SeasonCount       int     `json:"seasonCount"`
 		EpisodeFileCount  int     `json:"episodeFileCount"`
 		EpisodeCount      int     `json:"episodeCount"`
@@ -51,8 +60,9 @@ type CutoffUnmet struct {
 // EpisodeFile - Stores struct of JSON response
 // https://github.com/Sonarr/Sonarr/wiki/EpisodeFile
 type EpisodeFile []struct {
-	Size    int64 `json:"size"`
-	Quality struct {
+	Size      int64     `json:"size"`
+	DateAdded time.Time `json:"dateAdded"`
+	Quality   struct {
 		Quality struct {
 			ID         int    `json:"id"`
 			Name       string `json:"name"`
@@ -60,6 +70,9 @@ type EpisodeFile []struct {
 			Resolution int    `json:"resolution"`
 		} `json:"quality"`
 	} `json:"quality"`
+	MediaInfo         MediaInfo      `json:"mediaInfo"`
+	CustomFormats     []CustomFormat `json:"customFormats"`
+	CustomFormatScore int            `json:"customFormatScore"`
 }
 
 // Episode - Stores struct of JSON response
@@ -70,19 +70,33 @@ func (a arrCommand) runE(cmd *cobra.Command, _ []string) error {
 }
 
 // sharedArrCollectors returns the collectors common to the full *arr apps
-// (radarr, sonarr, lidarr): queue, root folder, disk space, status, health,
-// and — unless disabled — history.
+// (radarr, sonarr, lidarr): queue, root folder, disk space, status, health
+// with its per-check emitters, scheduled tasks, backups, quality profiles,
+// calendar, blocklist and pending releases, notifications, history and import
+// lists unless disabled, and logs when enabled.
 func sharedArrCollectors(httpClient *client.Client, c *config.ArrConfig) []prometheus.Collector {
 	out := []prometheus.Collector{
 		collector.NewQueueCollector(httpClient, c),
 		collector.NewRootFolderCollector(httpClient, c),
 		collector.NewDiskSpaceCollector(httpClient, c),
 		collector.NewSystemStatusCollector(httpClient, c),
-		collector.NewSystemHealthCollector(httpClient, c),
+		collector.NewSystemHealthCollector(httpClient, c, collector.SharedHealthEmitters(c)...),
+		collector.NewSystemTaskCollector(httpClient, c),
+		collector.NewBackupCollector(httpClient, c),
+		collector.NewQualityProfileCollector(httpClient, c),
+		collector.NewCalendarCollector(httpClient, c),
+		collector.NewBlocklistCollector(httpClient, c),
+		collector.NewNotificationCollector(httpClient, c),
 	}
 	if !c.DisableHistoryMetrics {
 		out = append(out, collector.NewHistoryCollector(httpClient, c))
 	}
+	if !c.DisableImportListMetrics {
+		out = append(out, collector.NewImportListCollector(httpClient, c))
+	}
+	if c.EnableLogMetrics {
+		out = append(out, collector.NewLogCollector(httpClient, c))
+	}
 	return out
 }
 
@@ -157,11 +171,16 @@ var prowlarrCmd = &cobra.Command{
 				collector.NewProwlarrCollector(httpClient, c),
 				collector.NewSystemStatusCollector(httpClient, c),
 				collector.NewSystemHealthCollector(httpClient, c,
-					collector.NewUnavailableIndexerEmitter(c.URL)),
+					collector.NewUnavailableIndexerEmitter(c.App, c.URL)),
+				collector.NewSystemTaskCollector(httpClient, c),
+				collector.NewBackupCollector(httpClient, c),
 			}
 			if !c.DisableHistoryMetrics {
 				out = append(out, collector.NewHistoryCollector(httpClient, c))
 			}
+			if c.EnableLogMetrics {
+				out = append(out, collector.NewLogCollector(httpClient, c))
+			}
 			return out
 		},
 	}.runE,
@@ -8,6 +8,7 @@ import (
 )
 
 func init() {
+	config.RegisterSabnzbdFlags(sabnzbdCmd.PersistentFlags())
 	rootCmd.AddCommand(sabnzbdCmd)
 }
 
@@ -16,8 +17,8 @@ var sabnzbdCmd = &cobra.Command{
 	Aliases: []string{"sab"},
 	Short:   "Prometheus Exporter for Sabnzbd",
 	Long:    "Prometheus Exporter for Sabnzbd.",
-	RunE: func(_ *cobra.Command, _ []string) error {
-		c, err := config.LoadSabnzbdConfig(*conf)
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := config.LoadSabnzbdConfig(*conf, cmd.PersistentFlags())
 		if err != nil {
 			return err
 		}
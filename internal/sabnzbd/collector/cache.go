@@ -115,3 +115,53 @@ func (c *ServersStatsCache) GetServerMap() map[string]ServerStats {
 
 	return ret
 }
+
+// serverStatState is the persisted form of one server's accumulated
+// statistics.
+type serverStatState struct {
+	Total                     int    `json:"total"`
+	ArticlesTriedHistorical   int    `json:"articlesTriedHistorical"`
+	ArticlesTriedToday        int    `json:"articlesTriedToday"`
+	ArticlesSuccessHistorical int    `json:"articlesSuccessHistorical"`
+	ArticlesSuccessToday      int    `json:"articlesSuccessToday"`
+	TodayKey                  string `json:"todayKey"`
+}
+
+// snapshot returns the per-server statistics in their persisted form.
+func (c *ServersStatsCache) snapshot() map[string]serverStatState {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	ret := make(map[string]serverStatState, len(c.Servers))
+	for name, s := range c.Servers {
+		ret[name] = serverStatState{
+			Total:                     s.total,
+			ArticlesTriedHistorical:   s.articlesTriedHistorical,
+			ArticlesTriedToday:        s.articlesTriedToday,
+			ArticlesSuccessHistorical: s.articlesSuccessHistorical,
+			ArticlesSuccessToday:      s.articlesSuccessToday,
+			TodayKey:                  s.todayKey,
+		}
+	}
+	return ret
+}
+
+// restore replaces the per-server statistics with a snapshot taken earlier.
+// The next Update folds the restored day into the historical counts if
+// SABnzbd has rolled over since.
+func (c *ServersStatsCache) restore(servers map[string]serverStatState) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.Servers = make(map[string]serverStatCache, len(servers))
+	for name, s := range servers {
+		c.Servers[name] = serverStatCache{
+			total:                     s.Total,
+			articlesTriedHistorical:   s.ArticlesTriedHistorical,
+			articlesTriedToday:        s.ArticlesTriedToday,
+			articlesSuccessHistorical: s.ArticlesSuccessHistorical,
+			articlesSuccessToday:      s.ArticlesSuccessToday,
+			todayKey:                  s.TodayKey,
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/onedr0p/exportarr/internal/sabnzbd/auth"
 	"github.com/onedr0p/exportarr/internal/sabnzbd/config"
 	"github.com/onedr0p/exportarr/internal/sabnzbd/model"
+	"github.com/onedr0p/exportarr/internal/state"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -197,6 +198,7 @@ type SabnzbdCollector struct {
 	cache                    *ServersStatsCache
 	client                   *client.Client
 	baseURL                  string
+	stateFile                string
 	queueQueryDuration       prometheus.Histogram
 	serverStatsQueryDuration prometheus.Histogram
 }
@@ -211,13 +213,45 @@ func NewSabnzbdCollector(config *config.SabnzbdConfig) (*SabnzbdCollector, error
 		return nil, fmt.Errorf("failed to build client: %w", err)
 	}
 
-	return &SabnzbdCollector{
+	collector := &SabnzbdCollector{
 		cache:                    NewServersStatsCache(),
 		client:                   client,
 		baseURL:                  config.URL,
+		stateFile:                config.StateFile,
 		queueQueryDuration:       newQueryDurationHistogram("queue", config.URL),
 		serverStatsQueryDuration: newQueryDurationHistogram("server_stats", config.URL),
-	}, nil
+	}
+	if collector.stateFile != "" {
+		collector.restoreState()
+	}
+	return collector, nil
+}
+
+// sabnzbdState is what the state file holds: the accumulated per-server
+// statistics, including the day each server's current counts belong to.
+type sabnzbdState struct {
+	Servers map[string]serverStatState `json:"servers"`
+}
+
+// restoreState loads the state file, if there is one. An unreadable state
+// file is logged and the collector starts fresh.
+func (s *SabnzbdCollector) restoreState() {
+	var st sabnzbdState
+	ok, err := state.Load(s.stateFile, &st)
+	if err != nil {
+		slog.Warn("Ignoring unreadable sabnzbd state file", "path", s.stateFile, "error", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	s.cache.restore(st.Servers)
+	slog.Info("Restored sabnzbd server stats", "path", s.stateFile, "servers", len(st.Servers))
+}
+
+// saveState writes the accumulated per-server statistics to the state file.
+func (s *SabnzbdCollector) saveState() error {
+	return state.Save(s.stateFile, sabnzbdState{Servers: s.cache.snapshot()})
 }
 
 // getJSON fetches a SABnzbd API mode and decodes the response into T.
@@ -338,7 +372,17 @@ func (s *SabnzbdCollector) Collect(ch chan<- prometheus.Metric) {
 			return fmt.Errorf("failed to get server stats: %w", err)
 		}
 
-		return s.cache.Update(*serverStats)
+		if err := s.cache.Update(*serverStats); err != nil {
+			return err
+		}
+		// A failed write only costs continuity on the next restart, so it
+		// doesn't fail the scrape.
+		if s.stateFile != "" {
+			if err := s.saveState(); err != nil {
+				log.Warn("Error saving sabnzbd state", "error", err)
+			}
+		}
+		return nil
 	})
 
 	if err := g.Wait(); err != nil {
@@ -5,10 +5,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/onedr0p/exportarr/internal/sabnzbd/config"
+	"github.com/onedr0p/exportarr/internal/state"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
@@ -114,3 +116,70 @@ func TestCollect_FailureDoesntPanic(t *testing.T) {
 	}, "Collecting metrics should not panic on failure")
 	assert.Error(t, err)
 }
+
+func TestCollect_StateFile(t *testing.T) {
+	ts, err := newTestServer(t, func(http.ResponseWriter, *http.Request) {})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	config := &config.SabnzbdConfig{
+		URL:       ts.URL,
+		APIKey:    testAPIKey,
+		StateFile: filepath.Join(t.TempDir(), "sabnzbd.json"),
+	}
+
+	// The previous run last saw server1 on the 28th, with counts from
+	// earlier rollovers already folded into the historical component.
+	assert.NoError(t, state.Save(config.StateFile, sabnzbdState{
+		Servers: map[string]serverStatState{
+			"server1.example.tld": {
+				ArticlesTriedHistorical:   2259,
+				ArticlesTriedToday:        8157,
+				ArticlesSuccessHistorical: 2259,
+				ArticlesSuccessToday:      8157,
+				TodayKey:                  "2022-12-28",
+			},
+		},
+	}))
+
+	collector, err := NewSabnzbdCollector(config)
+	assert.NoError(t, err)
+	assert.Equal(t, testutil.CollectAndCount(collector, "sabnzbd_server_articles_total"), 2)
+
+	servers := collector.cache.GetServerMap()
+	assert.Equal(t, servers["server1.example.tld"].GetArticlesTried(), 2259+8157+12622)
+	assert.Equal(t, servers["server1.example.tld"].GetArticlesSuccess(), 2259+8157+12618)
+
+	// A restart on the same day restores the counts rather than resetting them.
+	restarted, err := NewSabnzbdCollector(config)
+	assert.NoError(t, err)
+	assert.Equal(t, testutil.CollectAndCount(restarted, "sabnzbd_server_articles_total"), 2)
+
+	servers = restarted.cache.GetServerMap()
+	assert.Equal(t, servers["server1.example.tld"].GetArticlesTried(), 2259+8157+12622)
+	assert.Equal(t, servers["server2.example.tld"].GetArticlesTried(), 9869)
+
+	var st sabnzbdState
+	ok, err := state.Load(config.StateFile, &st)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, st.Servers["server1.example.tld"].TodayKey, "2022-12-29")
+}
+
+func TestCollect_UnreadableStateFile(t *testing.T) {
+	ts, err := newTestServer(t, func(http.ResponseWriter, *http.Request) {})
+	assert.NoError(t, err)
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "sabnzbd.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+
+	collector, err := NewSabnzbdCollector(&config.SabnzbdConfig{
+		URL:       ts.URL,
+		APIKey:    testAPIKey,
+		StateFile: path,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testutil.CollectAndCount(collector, "sabnzbd_server_articles_total"), 2)
+	assert.Equal(t, collector.cache.GetServerMap()["server1.example.tld"].GetArticlesTried(), 12622)
+}
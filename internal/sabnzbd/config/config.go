@@ -7,6 +7,9 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/caarlos0/env/v11"
+	flag "github.com/spf13/pflag"
+
 	base_config "github.com/onedr0p/exportarr/internal/config"
 )
 
@@ -16,16 +19,28 @@ type SabnzbdConfig struct {
 	APIKey           string
 	DisableSSLVerify bool
 	RequestTimeout   time.Duration
+	// StateFile persists the server stats cache across restarts when set.
+	StateFile string `env:"SABNZBD__STATE_FILE"`
+}
+
+// RegisterSabnzbdFlags registers SABnzbd-specific flags on the given FlagSet.
+func RegisterSabnzbdFlags(flags *flag.FlagSet) {
+	flags.String("state-file", "", "File to persist accumulated SABnzbd server stats in, so counters survive restarts")
 }
 
-// LoadSabnzbdConfig builds a SabnzbdConfig from the base configuration.
-func LoadSabnzbdConfig(conf base_config.Config) (*SabnzbdConfig, error) {
+// LoadSabnzbdConfig builds a SabnzbdConfig from the base configuration, the
+// environment and SABnzbd-specific flags.
+func LoadSabnzbdConfig(conf base_config.Config, flags *flag.FlagSet) (*SabnzbdConfig, error) {
 	ret := &SabnzbdConfig{
 		URL:              conf.URL,
 		APIKey:           conf.APIKey,
 		DisableSSLVerify: conf.DisableSSLVerify,
 		RequestTimeout:   conf.RequestTimeout,
 	}
+	if err := env.Parse(ret); err != nil {
+		return nil, err
+	}
+	base_config.OverlayFlag(flags, "state-file", flags.GetString, &ret.StateFile)
 	return ret, nil
 }
 
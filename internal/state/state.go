@@ -0,0 +1,61 @@
+// Package state persists exporter state, such as accumulated counters, to a
+// JSON file so it survives restarts.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Load decodes the JSON state file at path into v. A missing file is not an
+// error: it reports false so callers start fresh on first run.
+func Load(path string, v any) (bool, error) {
+	b, err := os.ReadFile(path) //nolint:gosec // operator-configured path
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading state file: %w", err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return false, fmt.Errorf("decoding state file %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// Save writes v as JSON to path atomically: it writes a temporary file in
+// the same directory, syncs it and renames it over path, so a crash or a
+// full disk mid-write never leaves a truncated state file behind.
+func Save(path string, v any) (err error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding state: %w", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating state file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+	if _, err = f.Write(b); err != nil {
+		return fmt.Errorf("writing state file: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("syncing state file: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("closing state file: %w", err)
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("replacing state file: %w", err)
+	}
+	return nil
+}
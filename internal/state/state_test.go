@@ -0,0 +1,53 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onedr0p/exportarr/internal/assert"
+)
+
+type testState struct {
+	Count int            `json:"count"`
+	Names map[string]int `json:"names"`
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	want := testState{Count: 3, Names: map[string]int{"a": 1, "b": 2}}
+	assert.NoError(t, Save(path, want))
+
+	var got testState
+	ok, err := Load(path, &got)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.DeepEqual(t, got, want)
+
+	// Saving again replaces the file and leaves no temporary files behind.
+	assert.NoError(t, Save(path, testState{Count: 4}))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	var got testState
+	ok, err := Load(filepath.Join(t.TempDir(), "missing.json"), &got)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+	var got testState
+	ok, err := Load(path, &got)
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
+
+func TestSave_MissingDirectory(t *testing.T) {
+	err := Save(filepath.Join(t.TempDir(), "nope", "state.json"), testState{})
+	assert.Error(t, err)
+}